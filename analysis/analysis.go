@@ -0,0 +1,301 @@
+package analysis
+
+import (
+	"../chess"
+	"../fen"
+	"../pgn"
+	"../search"
+	"../uci"
+)
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+var ErrNoInfo = errors.New("engine sent no info lines")
+
+// An Evaluation is the score of a position for the player to move,
+// using the scale of the search package (so mates are search.Mate
+// less the plies to mate), and the best line from it.
+type Evaluation struct {
+	Score int
+	PV []chess.Move
+}
+
+// An Evaluator scores positions, either with the local search or an
+// external engine. A position is given as the game's start and the
+// moves played from it, so that repetitions can be seen.
+type Evaluator interface {
+	Evaluate(start *chess.Game, moves []*chess.Move) (Evaluation, error)
+}
+
+// Local evaluates positions with a search.Searcher.
+type Local struct {
+	Searcher *search.Searcher
+	Limits search.Limits
+}
+
+// Engine evaluates positions with an external UCI engine.
+type Engine struct {
+	Client *uci.Client
+	Limits uci.Go
+}
+
+func (l *Local) Evaluate(start *chess.Game, moves []*chess.Move) (Evaluation, error) {
+	g := *start
+	limits := l.Limits
+
+	// the search is told about the positions before
+	limits.History = append([]uint64(nil), l.Limits.History...)
+
+	for _, m := range moves {
+		limits.History = append(limits.History, g.Hash())
+		g.PerformMove(m)
+	}
+
+	result := l.Searcher.Search(&g, limits)
+
+	return Evaluation{ Score: result.Score, PV: result.PV }, nil
+}
+
+func (e *Engine) Evaluate(start *chess.Game, moves []*chess.Move) (Evaluation, error) {
+	var eval Evaluation
+
+	g := *start
+	played := make([]string, len(moves))
+
+	for i, m := range moves {
+		played[i] = m.UCI()
+		g.PerformMove(m)
+	}
+
+	// a finished game isn't sent to the engine
+	if outcome := g.Outcome(); outcome != chess.InProgress {
+		return Evaluation{ Score: score(&g, outcome) }, nil
+	}
+
+	if err := e.Client.Position(fen.Format(start), played); err != nil {
+		return eval, err
+	}
+
+	a, err := e.Client.Go(e.Limits)
+
+	if err != nil {
+		return eval, err
+	}
+
+	// a silent engine isn't an even position
+	if len(a.Lines) == 0 {
+		return eval, ErrNoInfo
+	}
+
+	info := a.Lines[0]
+
+	switch {
+		case info.Mate > 0:  eval.Score = search.Mate - (info.Mate * 2 - 1); break
+		case info.Mate < 0:  eval.Score = -search.Mate - info.Mate * 2; break
+		default:             eval.Score = info.Score; break
+	}
+
+	// replay the line to check it
+	pos := g
+
+	for _, s := range info.PV {
+		move := pos.ParseUCI(s)
+
+		if move == nil {
+			break
+		}
+
+		eval.PV = append(eval.PV, *move)
+		pos.PerformMove(move)
+	}
+
+	return eval, nil
+}
+
+// score of a finished game for the player to move
+func score(g *chess.Game, outcome chess.Outcome) int {
+	switch outcome {
+		case chess.Win(g.Turn):             return search.Mate
+		case chess.Win(g.Turn.Opponent()):  return -search.Mate
+	}
+
+	return 0
+}
+
+// Thresholds are how many centipawns a move has to lose compared to
+// the best move to be marked.
+type Thresholds struct {
+	Inaccuracy int
+	Mistake int
+	Blunder int
+}
+
+var DefaultThresholds = Thresholds{
+	Inaccuracy: 50,
+	Mistake: 100,
+	Blunder: 300,
+}
+
+// Judgements, with the NAG each is marked with.
+const (
+	Good = 0
+	Inaccuracy = 6            // ?!
+	Mistake = 2               // ?
+	Blunder = 4               // ??
+)
+
+var judgementNames = map[int]string{
+	Inaccuracy: "Inaccuracy",
+	Mistake: "Mistake",
+	Blunder: "Blunder",
+}
+
+// the comment written with a judgement, so a game can be annotated again
+var reJudgement = regexp.MustCompile("^(Inaccuracy|Mistake|Blunder)\\. \\S+ was best\\.\\s*")
+
+// scores beyond this are all winning, so a drop between them is only
+// counted up to here
+const Decisive = 1000
+
+// Clamp limits a score to the decisive range, so that mates and huge
+// advantages compare sensibly.
+func Clamp(score int) int {
+	switch {
+		case score > Decisive:  return Decisive
+		case score < -Decisive: return -Decisive
+	}
+
+	return score
+}
+
+// Judge classifies a move by how much it lost compared to the best.
+func (t Thresholds) Judge(loss int) int {
+	switch {
+		case loss >= t.Blunder:     return Blunder
+		case loss >= t.Mistake:     return Mistake
+		case loss >= t.Inaccuracy:  return Inaccuracy
+	}
+
+	return Good
+}
+
+// Annotate evaluates every position of a game, and writes the evals
+// into the moves. Moves losing too much are marked with a NAG, a
+// comment, and the best line as a variation.
+func Annotate(game *pgn.PGN, ev Evaluator, t Thresholds) error {
+	g := game.Setup()
+
+	if g == nil {
+		return fmt.Errorf("unknown variant %s", game.Tags["Variant"])
+	}
+
+	start := *g
+
+	var played []*chess.Move
+
+	// evaluate the position before each move, and after the last
+	before, err := ev.Evaluate(&start, nil)
+
+	if err != nil {
+		return err
+	}
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m == nil {
+				continue
+			}
+
+			pos := *g
+			g.PerformMove(m.Move)
+			played = append(played, m.Move)
+
+			after, err := ev.Evaluate(&start, played)
+
+			if err != nil {
+				return err
+			}
+
+			m.Eval = whiteEval(g, after.Score)
+
+			// forget any earlier judgement
+			if reJudgement.MatchString(m.Comment) {
+				m.Comment = reJudgement.ReplaceAllString(m.Comment, "")
+				m.NAGs = withoutJudgements(m.NAGs)
+				m.Alternative = nil
+			}
+
+			// the drop for the player who moved
+			best := Clamp(before.Score)
+			played := Clamp(-after.Score)
+
+			if len(before.PV) > 0 && search.SameMove(&before.PV[0], m.Move) {
+				played = best
+			}
+
+			if j := t.Judge(best - played); j != Good && len(before.PV) > 0 {
+				m.NAGs = append(m.NAGs, j)
+				m.Comment = joinComment(fmt.Sprintf("%s. %s was best.", judgementNames[j], pos.SAN(&before.PV[0])), m.Comment)
+
+				if m.Alternative == nil {
+					m.Alternative = line(before.PV)
+				}
+			}
+
+			before = after
+		}
+	}
+
+	return nil
+}
+
+// whiteEval converts a score for the player to move into a PGN eval,
+// which is always from white's point of view. A game that is over has
+// no eval.
+func whiteEval(g *chess.Game, score int) *pgn.Eval {
+	if g.Turn == chess.Black {
+		score = -score
+	}
+
+	if search.IsMate(score) {
+		if mate := search.MateMoves(score); mate != 0 {
+			return &pgn.Eval{ Mate: mate }
+		}
+		return nil
+	}
+
+	return &pgn.Eval{ Centipawns: score }
+}
+
+func withoutJudgements(nags []int) []int {
+	kept := nags[:0]
+
+	for _, nag := range nags {
+		if nag != Inaccuracy && nag != Mistake && nag != Blunder {
+			kept = append(kept, nag)
+		}
+	}
+
+	return kept
+}
+
+func line(pv []chess.Move) []*chess.Move {
+	moves := make([]*chess.Move, len(pv))
+
+	for i := range pv {
+		moves[i] = &pv[i]
+	}
+
+	return moves
+}
+
+func joinComment(s, comment string) string {
+	if comment == "" {
+		return s
+	}
+	return s + " " + comment
+}
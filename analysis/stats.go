@@ -0,0 +1,281 @@
+package analysis
+
+import (
+	"../chess"
+	"../pgn"
+)
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+type Phase int
+
+const (
+	Opening Phase = iota
+	Middlegame
+	Endgame
+)
+
+var phaseNames = [...]string{ "opening", "middlegame", "endgame" }
+
+func (p Phase) String() string {
+	return phaseNames[p]
+}
+
+// PhaseOf guesses the phase of a position from the pieces left (not
+// counting kings and pawns) and the move number.
+func PhaseOf(g *chess.Game) Phase {
+	pieces := 0
+
+	for c := 0; c < 2; c++ {
+		for _, kind := range []chess.Kind{ chess.Bishop, chess.Knight, chess.Rook, chess.Queen } {
+			pieces += g.Bits.Pieces[c][kind].Count()
+		}
+	}
+
+	switch {
+		case pieces <= 6:                 return Endgame
+		case pieces <= 10 || g.Move > 12: return Middlegame
+	}
+
+	return Opening
+}
+
+// Stats are the totals for a player's moves. Loss and accuracy only
+// count moves with evals before and after them.
+type Stats struct {
+	Moves int                 `json:"moves"`
+	Evaluated int             `json:"evaluated"`
+	CentipawnLoss float64     `json:"acpl"`
+	Accuracy float64          `json:"accuracy"`
+	Inaccuracies int          `json:"inaccuracies"`
+	Mistakes int              `json:"mistakes"`
+	Blunders int              `json:"blunders"`
+
+	loss, accuracy float64    // sums for the averages
+}
+
+// Player is the statistics of one player over all of their games.
+type Player struct {
+	Name string               `json:"name"`
+	Games int                 `json:"games"`
+	Total Stats               `json:"total"`
+	Opening Stats             `json:"opening"`
+	Middlegame Stats          `json:"middlegame"`
+	Endgame Stats             `json:"endgame"`
+}
+
+// A Report collects player statistics from analyzed games.
+type Report struct {
+	Players []*Player         `json:"players"`
+
+	index map[string]*Player
+}
+
+// WinPercent is the chance of winning for a centipawn advantage.
+func WinPercent(cp int) float64 {
+	return 50 + 50 * (2 / (1 + math.Exp(-0.00368208 * float64(cp))) - 1)
+}
+
+// MoveAccuracy is how accurate a move was, from 0 to 100, given the
+// win percentages of the mover before and after it.
+func MoveAccuracy(before, after float64) float64 {
+	a := 103.1668 * math.Exp(-0.04354 * (before - after)) - 3.1669
+
+	return math.Max(0, math.Min(100, a))
+}
+
+func NewReport() *Report {
+	return &Report{ index: make(map[string]*Player) }
+}
+
+// Player returns the statistics for a name, adding it if new.
+func (r *Report) Player(name string) *Player {
+	if p, ok := r.index[name]; ok {
+		return p
+	}
+
+	p := &Player{ Name: name }
+
+	r.index[name] = p
+	r.Players = append(r.Players, p)
+
+	return p
+}
+
+func (p *Player) Phase(phase Phase) *Stats {
+	switch phase {
+		case Opening:    return &p.Opening
+		case Middlegame: return &p.Middlegame
+	}
+
+	return &p.Endgame
+}
+
+// Add counts the moves of an analyzed game. The evals written by
+// Annotate give the loss of each move, and the judgements it wrote
+// give the counts of inaccuracies, mistakes and blunders. A ?! or ?
+// from whoever annotated the game before isn't counted.
+func (r *Report) Add(game *pgn.PGN) error {
+	g := game.Setup()
+
+	if g == nil {
+		return fmt.Errorf("unknown variant %s", game.Tags["Variant"])
+	}
+
+	players := [2]*Player{
+		r.Player(name(game, "White")),
+		r.Player(name(game, "Black")),
+	}
+
+	players[chess.White].Games++
+
+	if players[chess.Black] != players[chess.White] {
+		players[chess.Black].Games++
+	}
+
+	// the position before the first move has no eval
+	var before *int
+
+	phase := Opening
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m == nil {
+				continue
+			}
+
+			// phases only move forward
+			if p := PhaseOf(g); p > phase {
+				phase = p
+			}
+
+			mover := g.Turn
+			player := players[mover]
+			g.PerformMove(m.Move)
+
+			after := whiteScore(g, m.Eval)
+
+			for _, s := range []*Stats{ &player.Total, player.Phase(phase) } {
+				s.Moves++
+
+				if before != nil && after != nil {
+					s.add(*before, *after, mover)
+				}
+
+				s.judge(m.Comment)
+			}
+
+			before = after
+		}
+	}
+
+	return nil
+}
+
+func name(game *pgn.PGN, tag string) string {
+	if s, ok := game.Tags[tag]; ok && s != "" {
+		return s
+	}
+	return "?"
+}
+
+// whiteScore is the centipawns of an eval from white's point of view,
+// or the result when the game is over, limited to the decisive range.
+func whiteScore(g *chess.Game, eval *pgn.Eval) *int {
+	var cp int
+
+	switch {
+		case eval != nil && eval.Mate > 0:  cp = Decisive; break
+		case eval != nil && eval.Mate < 0:  cp = -Decisive; break
+		case eval != nil:                   cp = Clamp(eval.Centipawns); break
+		default:
+			switch g.Outcome() {
+				case chess.InProgress:       return nil
+				case chess.Win(chess.White): cp = Decisive; break
+				case chess.Win(chess.Black): cp = -Decisive; break
+			}
+	}
+
+	return &cp
+}
+
+func (s *Stats) add(before, after int, mover chess.Color) {
+	if mover == chess.Black {
+		before, after = -before, -after
+	}
+
+	if loss := before - after; loss > 0 {
+		s.loss += float64(loss)
+	}
+
+	s.accuracy += MoveAccuracy(WinPercent(before), WinPercent(after))
+	s.Evaluated++
+
+	s.CentipawnLoss = s.loss / float64(s.Evaluated)
+	s.Accuracy = s.accuracy / float64(s.Evaluated)
+}
+
+// judge counts the judgement Annotate wrote in a move's comment.
+func (s *Stats) judge(comment string) {
+	m := reJudgement.FindStringSubmatch(comment)
+
+	if m == nil {
+		return
+	}
+
+	switch m[1] {
+		case judgementNames[Inaccuracy]: s.Inaccuracies++; break
+		case judgementNames[Mistake]:    s.Mistakes++; break
+		case judgementNames[Blunder]:    s.Blunders++; break
+	}
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteTable writes the report as a text table, a row for each player
+// followed by a row for each phase they played.
+func (r *Report) WriteTable(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%-24s %5s %5s %6s %8s %4s %4s %4s\n", "Player", "Games", "Moves", "ACPL", "Accuracy", "?!", "?", "??"); err != nil {
+		return err
+	}
+
+	for _, p := range r.Players {
+		if err := row(w, p.Name, fmt.Sprint(p.Games), &p.Total); err != nil {
+			return err
+		}
+
+		for phase := Opening; phase <= Endgame; phase++ {
+			if s := p.Phase(phase); s.Moves > 0 {
+				if err := row(w, "  " + phase.String(), "", s); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func row(w io.Writer, label, games string, s *Stats) error {
+	acpl, accuracy := "-", "-"
+
+	if s.Evaluated > 0 {
+		acpl = fmt.Sprintf("%.1f", s.CentipawnLoss)
+		accuracy = fmt.Sprintf("%.1f%%", s.Accuracy)
+	}
+
+	_, err := fmt.Fprintf(w, "%-24s %5s %5d %6s %8s %4d %4d %4d\n", label, games, s.Moves, acpl, accuracy, s.Inaccuracies, s.Mistakes, s.Blunders)
+
+	return err
+}
@@ -0,0 +1,204 @@
+package chess
+
+type Pin struct {
+	Tile int                  // the pinned piece
+	Pinner int                // the piece pinning it to the king
+	Ray Bitboard              // tiles the pinned piece can still move to
+}
+
+// Attackers returns every piece of a color attacking a tile.
+func (g *Game) Attackers(tile int, color Color) Bitboard {
+	if Offboard(tile) {
+		return 0
+	}
+
+	return g.Bits.Attackers(Square(tile), color, g.Bits.Occupied)
+}
+
+// Checkers returns the opponent pieces checking the current player.
+func (g *Game) Checkers() Bitboard {
+	if king := g.King[g.Turn]; king >= 0 {
+		return g.Attackers(king, g.Turn.Opponent())
+	}
+	return 0
+}
+
+// Pinned returns the pieces of a color that are pinned to its king,
+// along with the pinning piece and the ray it's pinned along.
+func (g *Game) Pinned(color Color) []Pin {
+	pins := make([]Pin, 0, 2)
+
+	if g.King[color] < 0 {
+		return pins
+	}
+
+	king := Square(g.King[color])
+
+	for snipers := g.snipers(king, color); snipers != 0; {
+		sq := snipers.Pop()
+
+		if pinned := g.pinnedBy(king, sq, color); pinned != 0 {
+			pins = append(pins, Pin{
+				Tile: SquareTile(pinned.First()),
+				Pinner: SquareTile(sq),
+				Ray: Between[king][sq] | SquareBit(sq),
+			})
+		}
+	}
+
+	return pins
+}
+
+// pinnedBits is every piece of a color pinned to its king, without the
+// allocations of Pinned.
+func (g *Game) pinnedBits(color Color) Bitboard {
+	var pinned Bitboard
+
+	if g.King[color] < 0 {
+		return 0
+	}
+
+	king := Square(g.King[color])
+
+	for snipers := g.snipers(king, color); snipers != 0; {
+		pinned |= g.pinnedBy(king, snipers.Pop(), color)
+	}
+
+	return pinned
+}
+
+// the piece of a color pinned to its king by a slider, if any
+func (g *Game) pinnedBy(king, sq int, color Color) Bitboard {
+	blockers := Between[king][sq] & g.Bits.Occupied
+
+	// exactly one of our pieces between the king and slider
+	if blockers.Count() == 1 && blockers & g.Bits.Colors[color] != 0 {
+		return blockers
+	}
+
+	return 0
+}
+
+// AttackMap counts the pieces of a color attacking each tile.
+func (g *Game) AttackMap(color Color) [128]int {
+	var counts [128]int
+
+	for kind := Pawn; kind <= Queen; kind++ {
+		for pieces := g.Bits.Pieces[color][kind]; pieces != 0; {
+			sq := pieces.Pop()
+
+			attacks := PawnAttacks[color][sq]
+
+			if kind != Pawn {
+				attacks = Attacks(kind, sq, g.Bits.Occupied)
+			}
+
+			for attacks != 0 {
+				counts[SquareTile(attacks.Pop())]++
+			}
+		}
+	}
+
+	return counts
+}
+
+// opponent sliders lined up with a square on an empty board
+func (g *Game) snipers(sq int, color Color) Bitboard {
+	p := &g.Bits.Pieces[color.Opponent()]
+
+	rooks := RookAttacks(sq, 0) & (p[Rook] | p[Queen])
+	bishops := BishopAttacks(sq, 0) & (p[Bishop] | p[Queen])
+
+	return rooks | bishops
+}
+
+// safety is what it takes to tell whether pseudo legal moves of the
+// current player leave their king attacked. The checkers and pins are
+// found once, so filtering doesn't need to make any moves.
+type safety struct {
+	king int                  // square of the king, -1 without one
+	checkers Bitboard
+	pinned Bitboard
+	block Bitboard            // tiles that capture or block a checker
+}
+
+func (g *Game) safety() safety {
+	if g.King[g.Turn] < 0 {
+		return safety{ king: -1 }
+	}
+
+	s := safety{
+		king: Square(g.King[g.Turn]),
+		checkers: g.Checkers(),
+		pinned: g.pinnedBits(g.Turn),
+		block: ^Bitboard(0),
+	}
+
+	// a single checker can be captured or blocked
+	if s.checkers != 0 {
+		s.block = s.checkers | Between[s.king][s.checkers.First()]
+	}
+
+	return s
+}
+
+// safe is true when a pseudo legal move doesn't leave the king attacked.
+func (s *safety) safe(g *Game, move *Move) bool {
+	if s.king < 0 {
+		return true
+	}
+
+	opp := g.Turn.Opponent()
+	origin, dest := Square(move.Origin), Square(move.Dest)
+
+	switch {
+		case move.Castle != 0:
+			if s.checkers != 0 {
+				return false
+			}
+
+			// the king can't pass through an attacked tile
+			for sq := origin; sq != dest; {
+				if sq < dest { sq++ } else { sq-- }
+
+				if g.Bits.Attacked(sq, opp) {
+					return false
+				}
+			}
+
+			return true
+
+		case origin == s.king:
+			occ := g.Bits.Occupied &^ SquareBit(s.king)
+
+			// slide attacks can't be blocked by the king itself
+			return g.Bits.Attackers(dest, opp, occ) == 0
+
+		case move.EnPassant:
+			bits := g.Bits
+			bits.Perform(g.Turn, move)
+
+			// two pawns leave the rank at once, so just try it
+			return bits.Attacked(s.king, opp) == false
+	}
+
+	if s.checkers.Count() > 1 {
+		return false
+	}
+
+	if s.block.Has(dest) == false {
+		return false
+	}
+
+	return s.pinned.Has(origin) == false || Line[s.king][origin].Has(dest)
+}
+
+// filterSafe keeps the pseudo legal moves in a list that don't leave
+// the king attacked.
+func (g *Game) filterSafe(list *MoveList) {
+	s := g.safety()
+
+	list.Filter(func(move *Move) bool {
+		return s.safe(g, move)
+	})
+}
@@ -0,0 +1,288 @@
+package chess
+
+import "math/bits"
+
+// A Bitboard has one bit per square, a1 is bit 0 and h8 is bit 63.
+type Bitboard uint64
+
+// Bitboards is the same position as a Board, with a bitboard for
+// every color and kind of piece. Move generation and attack
+// detection run on these.
+type Bitboards struct {
+	Pieces [2][6]Bitboard     // pieces of each color and kind
+	Colors [2]Bitboard        // all the pieces of each color
+	Occupied Bitboard         // every piece on the board
+}
+
+// precomputed attacks for non-sliding pieces
+var KnightAttacks [64]Bitboard
+var KingAttacks [64]Bitboard
+var PawnAttacks [2][64]Bitboard
+
+// rays along the directions of PieceDelta[Queen]; the first 4
+// are rook directions and the last 4 bishop directions
+var Rays [8][64]Bitboard
+
+// squares strictly between two squares, and the entire line through
+// both of them (empty if they don't share a rank, file or diagonal)
+var Between [64][64]Bitboard
+var Line [64][64]Bitboard
+
+func init() {
+	for sq := 0; sq < 64; sq++ {
+		tile := SquareTile(sq)
+
+		for _, d := range PieceDelta[Knight] {
+			if Offboard(tile + d) == false {
+				KnightAttacks[sq] |= TileBit(tile + d)
+			}
+		}
+
+		for _, d := range PieceDelta[King] {
+			if Offboard(tile + d) == false {
+				KingAttacks[sq] |= TileBit(tile + d)
+			}
+		}
+
+		// pawn attack table is from the target to the pawn
+		for _, c := range [2]Color{ White, Black } {
+			for _, d := range PawnAttackTable[c] {
+				if Offboard(tile - d) == false {
+					PawnAttacks[c][sq] |= TileBit(tile - d)
+				}
+			}
+		}
+
+		for i, d := range PieceDelta[Queen] {
+			for x := tile + d; !Offboard(x); x += d {
+				Rays[i][sq] |= TileBit(x)
+			}
+		}
+	}
+
+	for sq := 0; sq < 64; sq++ {
+		for i := range PieceDelta[Queen] {
+			line := Rays[i][sq] | Rays[opposite(i)][sq] | SquareBit(sq)
+
+			for ray := Rays[i][sq]; ray != 0; {
+				x := ray.Pop()
+
+				Between[sq][x] = Rays[i][sq] ^ Rays[i][x] ^ SquareBit(x)
+				Line[sq][x] = line
+			}
+		}
+	}
+}
+
+func opposite(i int) int {
+	for j, d := range PieceDelta[Queen] {
+		if d == -PieceDelta[Queen][i] {
+			return j
+		}
+	}
+	return i
+}
+
+func Square(tile int) int {
+	return Rank(tile) << 3 | File(tile)
+}
+
+func SquareTile(sq int) int {
+	return Tile(sq >> 3, sq & 7)
+}
+
+func SquareBit(sq int) Bitboard {
+	return 1 << uint(sq)
+}
+
+func TileBit(tile int) Bitboard {
+	return SquareBit(Square(tile))
+}
+
+func (b Bitboard) Has(sq int) bool {
+	return b & SquareBit(sq) != 0
+}
+
+func (b Bitboard) Count() int {
+	return bits.OnesCount64(uint64(b))
+}
+
+func (b Bitboard) First() int {
+	return bits.TrailingZeros64(uint64(b))
+}
+
+func (b Bitboard) Last() int {
+	return 63 - bits.LeadingZeros64(uint64(b))
+}
+
+func (b Bitboard) Tiles() []int {
+	tiles := make([]int, 0, b.Count())
+
+	for b != 0 {
+		tiles = append(tiles, SquareTile(b.Pop()))
+	}
+
+	return tiles
+}
+
+func (b *Bitboard) Pop() int {
+	sq := b.First()
+	*b &= *b - 1
+	return sq
+}
+
+func slide(i, sq int, occ Bitboard) Bitboard {
+	ray := Rays[i][sq]
+
+	// cut the ray off after the first blocker
+	if blockers := ray & occ; blockers != 0 {
+		if PieceDelta[Queen][i] > 0 {
+			ray ^= Rays[i][blockers.First()]
+		} else {
+			ray ^= Rays[i][blockers.Last()]
+		}
+	}
+
+	return ray
+}
+
+func RookAttacks(sq int, occ Bitboard) Bitboard {
+	return slide(0, sq, occ) | slide(1, sq, occ) | slide(2, sq, occ) | slide(3, sq, occ)
+}
+
+func BishopAttacks(sq int, occ Bitboard) Bitboard {
+	return slide(4, sq, occ) | slide(5, sq, occ) | slide(6, sq, occ) | slide(7, sq, occ)
+}
+
+// Attacks returns the tiles a non-pawn piece attacks from a square.
+func Attacks(kind Kind, sq int, occ Bitboard) Bitboard {
+	switch kind {
+		case Knight: return KnightAttacks[sq]
+		case King:   return KingAttacks[sq]
+		case Bishop: return BishopAttacks(sq, occ)
+		case Rook:   return RookAttacks(sq, occ)
+		case Queen:  return BishopAttacks(sq, occ) | RookAttacks(sq, occ)
+	}
+
+	return 0
+}
+
+func (b *Board) Bitboards() Bitboards {
+	var bb Bitboards
+
+	for sq := 0; sq < 64; sq++ {
+		if p := b[SquareTile(sq)]; p != nil {
+			bb.Place(sq, p.Color, p.Kind)
+		}
+	}
+
+	return bb
+}
+
+func (bb *Bitboards) Board() Board {
+	var b Board
+
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			for pieces := bb.Pieces[c][kind]; pieces != 0; {
+				b.Place(SquareTile(pieces.Pop()), c, kind)
+			}
+		}
+	}
+
+	return b
+}
+
+func (bb *Bitboards) Place(sq int, color Color, kind Kind) {
+	bb.Remove(sq)
+
+	// add the piece
+	bb.Pieces[color][kind] |= SquareBit(sq)
+	bb.Colors[color] |= SquareBit(sq)
+	bb.Occupied |= SquareBit(sq)
+}
+
+func (bb *Bitboards) Remove(sq int) {
+	if bb.Occupied.Has(sq) == false {
+		return
+	}
+
+	mask := ^SquareBit(sq)
+
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			bb.Pieces[c][kind] &= mask
+		}
+
+		bb.Colors[c] &= mask
+	}
+
+	bb.Occupied &= mask
+}
+
+func (bb *Bitboards) Move(origin, dest int) {
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			if bb.Pieces[c][kind].Has(origin) {
+				bb.Remove(origin)
+				bb.Place(dest, c, kind)
+				return
+			}
+		}
+	}
+}
+
+// Attackers returns all the pieces of a color attacking a square
+// given the occupancy of the board, which allows x-rays.
+func (bb *Bitboards) Attackers(sq int, color Color, occ Bitboard) Bitboard {
+	p := &bb.Pieces[color]
+
+	// pawns attack the square from where an opponent pawn would
+	attackers := PawnAttacks[color.Opponent()][sq] & p[Pawn]
+
+	attackers |= KnightAttacks[sq] & p[Knight]
+	attackers |= KingAttacks[sq] & p[King]
+	attackers |= BishopAttacks(sq, occ) & (p[Bishop] | p[Queen])
+	attackers |= RookAttacks(sq, occ) & (p[Rook] | p[Queen])
+
+	return attackers & occ
+}
+
+func (bb *Bitboards) Attacked(sq int, color Color) bool {
+	return bb.Attackers(sq, color, bb.Occupied) != 0
+}
+
+// Perform plays a move for a color on the bitboards only. Variant
+// side effects are not applied.
+func (bb *Bitboards) Perform(color Color, move *Move) {
+	if move.Castle != 0 {
+		rank := BackRank[color]
+
+		switch move.Castle {
+			case Kingside:
+				bb.Move(Square(Tile(rank, 4)), Square(Tile(rank, 6)))
+				bb.Move(Square(Tile(rank, 7)), Square(Tile(rank, 5)))
+				break
+			case Queenside:
+				bb.Move(Square(Tile(rank, 4)), Square(Tile(rank, 2)))
+				bb.Move(Square(Tile(rank, 0)), Square(Tile(rank, 3)))
+				break
+		}
+
+		return
+	}
+
+	dest := Square(move.Dest)
+
+	// remove any captured piece and move
+	bb.Remove(dest)
+	bb.Move(Square(move.Origin), dest)
+
+	if move.EnPassant {
+		bb.Remove(Square(move.Dest + PieceDelta[Pawn][color.Opponent()]))
+	}
+
+	if move.Promote {
+		bb.Place(dest, color, move.Kind)
+	}
+}
@@ -1,14 +1,14 @@
 package chess
 
 import (
-	"fmt"
 	"regexp"
+	"strings"
 	"unicode"
 )
 
 // regular expression for parsing short-/long-hand algebraic moves
 var reMove = regexp.MustCompile(
-	"^O-(?:O-)?O|([PNBRQK])?([a-h]?[1-8]?)(x|-)?([a-h][1-8])(=[NBRQ])?[+#]?$",
+	"^O-(?:O-)?O|([PNBRQK])?([a-h]?[1-8]?)(x|-)?([a-h][1-8])(=[NBRQK])?[+#]?$",
 )
 
 var PawnAttackTable = [2][]int{
@@ -23,12 +23,13 @@ const (
 	Attack_K  = (1 << uint(King))
 )
 
+// each attack direction is a ray, blocked by the first piece on it
 var AttackTable = map[int][][]int{
 	Attack_BQ: [][]int{
 		[]int{ 15, 30, 45, 60, 75, 90, 105 }, // up left
 		[]int{ 17, 34, 51, 68, 85, 102, 119 }, // up right
-		[]int{ -15, -30, -45, -60, -75, -90, -105 }, // down left
-		[]int{ -17, -34, -51, -68, -85, -102, -119 }, // down right
+		[]int{ -15, -30, -45, -60, -75, -90, -105 }, // down right
+		[]int{ -17, -34, -51, -68, -85, -102, -119 }, // down left
 	},
 	Attack_RQ: [][]int{
 		[]int{ 16, 32, 48, 64, 80, 96, 112 }, // up
@@ -36,266 +37,225 @@ var AttackTable = map[int][][]int{
 		[]int{ -16, -32, -48, -64, -80, -96, -112 }, // down
 		[]int{ -1, -2, -3, -4, -5, -6, -7 }, // left
 	},
-	Attack_N: [][]int{ PieceDelta[Knight] },
-	Attack_K: [][]int{ PieceDelta[King] },
+	Attack_N: [][]int{
+		[]int{ -31 }, []int{ -33 }, []int{ -14 }, []int{ 18 },
+		[]int{ -18 }, []int{ 14 }, []int{ 31 }, []int{ 33 },
+	},
+	Attack_K: [][]int{
+		[]int{ -1 }, []int{ 1 }, []int{ -16 }, []int{ 16 },
+		[]int{ -17 }, []int{ 15 }, []int{ -15 }, []int{ 17 },
+	},
 }
 
+// IsLegalMove is true when a move is one of the legal moves of the
+// player to move.
 func (g *Game) IsLegalMove(move *Move) bool {
-	p := g.Position.Piece(move.Origin)
-	x := g.Position.Piece(move.Dest)
+	var list MoveList
 
-	if Offboard(move.Dest) || p == nil {
-		return false
-	}
-
-	// the piece exists and is owned by the current player
-	if p == nil || p.Color != g.Turn {
-		return false
-	}
+	g.GenerateMoves(&list)
 
-	if move.Castle != 0 {
-		if g.Castles & (move.Castle << uint(g.Turn << 2)) == 0 {
-			return false
-		}
+	return list.Contains(func(m *Move) bool {
+		return m.Origin == move.Origin &&
+			m.Dest == move.Dest &&
+			m.Castle == move.Castle &&
+			m.Promote == move.Promote &&
+			m.Kind == move.Kind
+	})
+}
 
-		// queenside castles move down in file, kingside up
-		if move.Castle == Kingside {
-			for i := 0; i < 3; i++ {
-				if g.InCheck(g.King[g.Turn] + i) {
-					return false
-				}
-			}
-		} else {
-			for i := 0; i < 3; i++ {
-				if g.InCheck(g.King[g.Turn] - i) {
-					return false
-				}
-			}
-		}
+func (g *Game) InCheck(tile int) bool {
+	return g.Attacked(tile, g.Turn.Opponent())
+}
 
-		return true
+func (g *Game) Attacked(tile int, color Color) bool {
+	if Offboard(tile) {
+		return false
 	}
 
-	if move.Pawn {
-		if p.Kind != Pawn {
-			return false
-		}
-
-		// can only push pawns on the pawn rank
-		if move.Push && Rank(move.Origin) != PawnRank[g.Turn] {
-			return false
-		}
-
-		// make sure en passant is valid
-		if move.EnPassant && move.Dest != g.EnPassant {
-			return false
-		}
-	}
+	return g.Bits.Attacked(Square(tile), color)
+}
 
-	// undo regardless
-	defer func() {
-		g.Position[move.Origin] = p
-		g.Position[move.Dest] = x
-	}()
+func (g *Game) CollectMoves() []*Move {
+	var list MoveList
 
-	// make move
-	g.Position[move.Origin] = nil
-	g.Position[move.Dest] = p
+	// generate into a list and copy the moves out of it
+	g.GenerateMoves(&list)
 
-	// get the king's location
-	king := g.King[g.Turn]
+	values := make([]Move, list.Len)
+	moves := make([]*Move, list.Len)
 
-	// if the king moved, update the king's location
-	if move.Kind == King {
-		king = move.Dest
+	for i := range values {
+		values[i] = list.Moves[i]
+		moves[i] = &values[i]
 	}
 
-	return g.InCheck(king) == false
+	return moves
 }
 
-func (g *Game) InCheck(tile int) bool {
-	opp := g.Turn.Opponent()
-
-	// check for simple pawn attacks
-	for delta := range PawnAttackTable[opp] {
-		if p := g.Position.Piece(tile + delta); p != nil {
-			if p.Color == g.Turn.Opponent() && p.Kind == Pawn {
-				return true
-			}
-		}
-	}
-
-	// non-pawn attacking pieces
-	for pieces, attacks := range AttackTable {
-		for _, direction := range attacks {
-			for _, delta := range direction {
-				if p := g.Position.Piece(tile + delta); p != nil {
-					if pieces & (1 << uint(p.Kind)) != 0 {
-						if p.Color == opp {
-							return true
-						}
-					}
-				}
-			}
-		}
-	}
+func (g *Game) GenerateMoves(list *MoveList) {
+	g.GenerateStage(list, AllMoves)
+}
 
-	return false
+func (g *Game) legalMoves(list *MoveList) {
+	g.stageMoves(list, AllMoves)
 }
 
-func (g *Game) CollectMoves() []*Move {
-	pseudoMoves := make(chan *Move)
-	moves := make([]*Move, 0, 30)
-
-	go func() {
-		for rank := 0; rank < 8; rank++ {
-			for file := 0; file < 8; file++ {
-				tile := Tile(rank, file)
-
-				// only collect moves for this player
-				if p := g.Position[tile]; p != nil && p.Color == g.Turn {
-					if p.Kind == Pawn {
-						g.PawnMoves(pseudoMoves, tile)
-					} else {
-						g.NonPawnMoves(pseudoMoves, tile, p.Kind)
-					}
-				}
-			}
-		}
+// PseudoLegalMoves collects the moves for the current player with a
+// destination in targets, without checking their legality.
+func (g *Game) PseudoLegalMoves(list *MoveList, targets Bitboard) {
+	list.Clear()
 
-		// add castling moves
-		g.CastleMoves(pseudoMoves)
+	for kind := Pawn; kind <= Queen; kind++ {
+		pieces := g.Bits.Pieces[g.Turn][kind]
 
-		// all pseudo legal moves have been collected
-		close(pseudoMoves)
-	}()
+		// only collect moves for this player
+		for pieces != 0 {
+			tile := SquareTile(pieces.Pop())
 
-	// filter legal moves from the pseudo legal ones
-	for move := range pseudoMoves {
-		if g.IsLegalMove(move) {
-			moves = append(moves, move)
+			if kind == Pawn {
+				g.PawnMoves(list, tile, targets)
+			} else {
+				g.NonPawnMoves(list, tile, kind, targets)
+			}
 		}
 	}
 
-	return moves
+	// add castling moves
+	g.CastleMoves(list, targets)
 }
 
-func (g *Game) PawnMoves(ch chan *Move, tile int) {
+func (g *Game) PawnMoves(list *MoveList, tile int, targets Bitboard) {
 	d := PieceDelta[Pawn][g.Turn]
 	x := tile + d
 
-	// advance forward once (can't be off board)
-	if g.Position[x] == nil {
-		ch <- &Move{
-			Origin: tile,
-			Dest: x,
-			Pawn: true,
-			Promote: Rank(x) == BackRank[g.Turn.Opponent()],
+	// pawns reaching the back rank must promote
+	if Offboard(x) {
+		return
+	}
+
+	// advance forward once
+	if g.Bits.Occupied.Has(Square(x)) == false {
+		if targets.Has(Square(x)) {
+			g.pawnMove(list, Move{
+				Origin: tile,
+				Dest: x,
+				Pawn: true,
+			})
 		}
 
 		// try pushing the pawn?
-		if Rank(tile) == PawnRank[g.Turn] {
-			if g.Position[x + d] == nil {
-				ch <- &Move{
+		if g.rules().PawnPush(g, tile) && targets.Has(Square(x + d)) {
+			if g.Bits.Occupied.Has(Square(x + d)) == false {
+				list.Add(Move{
 					Origin: tile,
 					Dest: x + d,
 					Pawn: true,
 					Push: true,
-				}
+				})
 			}
 		}
 	}
 
-	// capturing and en passant
-	for _, i := range [2]int{ -1, 1 } {
-		if Offboard(x + i) {
-			continue
-		}
+	attacks := PawnAttacks[g.Turn][Square(tile)]
 
-		// en passant capture?
-		if tile + d + i == g.EnPassant {
-			ch <- &Move{
+	// en passant capture, targeting either tile?
+	if g.EnPassant >= 0 && attacks.Has(Square(g.EnPassant)) {
+		x := g.EnPassant + PieceDelta[Pawn][g.Turn.Opponent()]
+
+		if targets.Has(Square(g.EnPassant)) || targets.Has(Square(x)) {
+			list.Add(Move{
 				Origin: tile,
-				Dest: x + i,
+				Dest: g.EnPassant,
 				Pawn: true,
 				Capture: true,
 				EnPassant: true,
-			}
-		} else {
-			p := g.Position[x + i]
-
-			if p != nil && p.Color != g.Turn {
-				ch <- &Move{
-					Origin: tile,
-					Dest: x + i,
-					Pawn: true,
-					Capture: true,
-					Promote: Rank(x + i) == BackRank[g.Turn.Opponent()],
-				}
-			}
+			})
 		}
 	}
+
+	// capturing
+	for captures := attacks & g.Bits.Colors[g.Turn.Opponent()] & targets; captures != 0; {
+		g.pawnMove(list, Move{
+			Origin: tile,
+			Dest: SquareTile(captures.Pop()),
+			Pawn: true,
+			Capture: true,
+		})
+	}
 }
 
-func (g *Game) NonPawnMoves(ch chan *Move, tile int, kind Kind) {
-	for _, d := range PieceDelta[kind] {
-		capture := false
-
-		// sliding pieces keep moving along that direction
-		for x := tile + d; !Offboard(x) && !capture; x += d {
-			if p := g.Position.Piece(x); p != nil {
-				if p.Color != g.Turn {
-					capture = true
-				} else {
-					break
-				}
-			}
+func (g *Game) pawnMove(list *MoveList, move Move) {
+	if Rank(move.Dest) != BackRank[g.Turn.Opponent()] {
+		list.Add(move)
+		return
+	}
 
-			ch <- &Move{
-				Origin: tile,
-				Dest: x,
-				Capture: capture,
-				Kind: kind,
-			}
+	// one move for each piece the pawn may promote to
+	for _, kind := range g.rules().Promotions() {
+		move.Promote = true
+		move.Kind = kind
 
-			if kind.Sliding() == false {
-				break
-			}
-		}
+		list.Add(move)
 	}
 }
 
-func (g *Game) CastleMoves(ch chan *Move) {
+func (g *Game) NonPawnMoves(list *MoveList, tile int, kind Kind, targets Bitboard) {
+	sq := Square(tile)
+	opp := g.Bits.Colors[g.Turn.Opponent()]
+
+	// every attacked tile not occupied by one of our own pieces
+	targets &= Attacks(kind, sq, g.Bits.Occupied) &^ g.Bits.Colors[g.Turn]
+
+	for targets != 0 {
+		x := targets.Pop()
+
+		list.Add(Move{
+			Origin: tile,
+			Dest: SquareTile(x),
+			Capture: opp.Has(x),
+			Kind: kind,
+		})
+	}
+}
+
+func (g *Game) CastleMoves(list *MoveList, targets Bitboard) {
 	tile := Tile(BackRank[g.Turn], 4)
 
+	// the king has to be home to castle
+	if g.King[g.Turn] != tile {
+		return
+	}
+
+	occ := g.Bits.Occupied
+
 	// is the kingside castle available?
 	if g.Castles & (Kingside << uint(g.Turn << 2)) != 0 {
-		b := g.Position.Piece(tile + 1) == nil
-		n := g.Position.Piece(tile + 2) == nil
+		b := occ.Has(Square(tile + 1)) == false
+		n := occ.Has(Square(tile + 2)) == false
 
-		if b && n /* bishop and knight */ {
-			ch <- &Move{
+		if b && n /* bishop and knight */ && targets.Has(Square(tile + 2)) {
+			list.Add(Move{
 				Origin: tile,
 				Dest: tile + 2,
 				Castle: Kingside,
 				Kind: King,
-			}
+			})
 		}
 	}
 
 	// is the queenside castle available?
 	if g.Castles & (Queenside << uint(g.Turn << 2)) != 0 {
-		q := g.Position.Piece(tile - 1) == nil
-		b := g.Position.Piece(tile - 2) == nil
-		n := g.Position.Piece(tile - 3) == nil
+		q := occ.Has(Square(tile - 1)) == false
+		b := occ.Has(Square(tile - 2)) == false
+		n := occ.Has(Square(tile - 3)) == false
 
-		if q && b && n /* queen, bishop, knight */ {
-			ch <- &Move{
+		if q && b && n /* queen, bishop, knight */ && targets.Has(Square(tile - 2)) {
+			list.Add(Move{
 				Origin: tile,
 				Dest: tile - 2,
 				Castle: Queenside,
 				Kind: King,
-			}
+			})
 		}
 	}
 }
@@ -306,23 +266,33 @@ func (g *Game) ParseMove(s string) *Move {
 	var k Kind
 	var move *Move
 
+	// castling is sometimes written with zeros
+	s = strings.Replace(s, "0", "O", -1)
+
 	// try and parse the move string
 	if m = reMove.FindStringSubmatch(s); m == nil {
-		fmt.Println("HERE")
 		return nil
 	}
 
 	// get all the available moves
 	moves := g.CollectMoves()
 
-	fmt.Println(moves)
-
 	// check for a castling move
 	switch m[0] {
 		case "O-O":   castle = Kingside; break
 		case "O-O-O": castle = Queenside; break
 	}
 
+	if castle != 0 {
+		for _, move := range moves {
+			if move.Castle == castle {
+				return move
+			}
+		}
+
+		return nil
+	}
+
 	// get the piece kind being moved
 	switch m[1] {
 		case "P", "": k = Pawn; break
@@ -344,6 +314,17 @@ func (g *Game) ParseMove(s string) *Move {
 
 	// check for a pawn promotion
 	promote := len(m[5]) > 0
+	promotion := Pawn
+
+	if promote {
+		switch m[5][1] {
+			case 'N': promotion = Knight; break
+			case 'B': promotion = Bishop; break
+			case 'R': promotion = Rook; break
+			case 'Q': promotion = Queen; break
+			case 'K': promotion = King; break
+		}
+	}
 
 	// determine if this move can match
 	filter := func(move *Move) bool {
@@ -354,6 +335,11 @@ func (g *Game) ParseMove(s string) *Move {
 			case move.Promote != promote: return false
 		}
 
+		// promoting to the same piece
+		if promote && move.Kind != promotion {
+			return false
+		}
+
 		// pawn move or same piece being moved
 		return move.Pawn && k == Pawn || move.Kind == k
 	}
@@ -370,7 +356,6 @@ func (g *Game) ParseMove(s string) *Move {
 
 	// no matching legal moves left?
 	if len(moves) == 0 {
-		fmt.Println("HERE2")
 		return nil
 	}
 
@@ -398,9 +383,8 @@ func (g *Game) ParseMove(s string) *Move {
 		if file >= 0 && File(moves[i].Origin) != file { continue }
 		if rank >= 0 && Rank(moves[i].Origin) != rank { continue }
 
-		// the rank or the file matches
+		// the rank or the file matches, so it's ambiguous
 		if move != nil {
-			fmt.Println("HERE3")
 			return nil
 		}
 
@@ -408,10 +392,5 @@ func (g *Game) ParseMove(s string) *Move {
 		move = moves[i]
 	}
 
-	// set the promotion kind for the move
-	if move.Promote && promote {
-		move.Kind = k
-	}
-
 	return move
 }
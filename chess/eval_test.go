@@ -0,0 +1,45 @@
+package chess_test
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import "testing"
+
+// tile of a square name like e4
+func tile(s string) int {
+	return chess.Tile(int(s[1] - '1'), int(s[0] - 'a'))
+}
+
+var legalCases = []struct {
+	name string
+	fen string
+	move chess.Move
+	legal bool
+}{
+	{ "white pawn push", start, chess.Move{ Origin: tile("e2"), Dest: tile("e4"), Kind: chess.Pawn, Pawn: true, Push: true }, true },
+	{ "knight move", start, chess.Move{ Origin: tile("g1"), Dest: tile("f3"), Kind: chess.Knight }, true },
+	{ "black pawn push on white's turn", start, chess.Move{ Origin: tile("e7"), Dest: tile("e5"), Kind: chess.Pawn, Pawn: true, Push: true }, false },
+	{ "empty origin", start, chess.Move{ Origin: tile("e4"), Dest: tile("e5"), Kind: chess.Pawn, Pawn: true }, false },
+	{ "castle through pieces", start, chess.Move{ Origin: tile("e1"), Dest: tile("g1"), Kind: chess.King, Castle: chess.Kingside }, false },
+	{ "castle", "4k3/8/8/8/8/8/8/4K2R w K - 0 1", chess.Move{ Origin: tile("e1"), Dest: tile("g1"), Kind: chess.King, Castle: chess.Kingside }, true },
+	{ "castle without rights", "4k3/8/8/8/8/8/8/4K2R w - - 0 1", chess.Move{ Origin: tile("e1"), Dest: tile("g1"), Kind: chess.King, Castle: chess.Kingside }, false },
+	{ "rook move", "4k3/8/8/8/8/8/8/4K2R w - - 0 1", chess.Move{ Origin: tile("h1"), Dest: tile("h8"), Kind: chess.Rook }, true },
+	{ "pinned knight", "4k3/4r3/8/8/8/8/4N3/4K3 w - - 0 1", chess.Move{ Origin: tile("e2"), Dest: tile("c3"), Kind: chess.Knight }, false },
+	{ "wrong promotion", "8/P3k3/8/8/8/8/8/4K3 w - - 0 1", chess.Move{ Origin: tile("a7"), Dest: tile("a8"), Kind: chess.King, Pawn: true, Promote: true }, false },
+}
+
+func TestIsLegalMove(t *testing.T) {
+	for _, c := range legalCases {
+		g := fen.Parse(c.fen)
+
+		if g == nil {
+			t.Fatalf("%s: invalid FEN %s", c.name, c.fen)
+		}
+
+		if legal := g.IsLegalMove(&c.move); legal != c.legal {
+			t.Errorf("%s: legal is %v, want %v", c.name, legal, c.legal)
+		}
+	}
+}
@@ -2,26 +2,29 @@ package chess
 
 type Game struct {
 	Position Board            // 0x88 board representation
+	Bits Bitboards            // bitboards of the same position
 	Turn Color                // whose turn it is
 	King [2]int               // location of king pieces
 	EnPassant int             // en passant availability
 	Castles int               // castling availability
 	HalfMove int              // pawn half moves
 	Move int                  // current full move
+	Checks [2]int             // checks given by each player
+	Variant Variant           // rules being played (nil is standard)
 }
 
 type Move struct {
 	Origin, Dest int          // where it is moving from and to
-	Capture bool              // captured another piece
 	Castle int                // castle move: Kingside or Queenside
 	Check int                 // check and/or mate
+	Kind Kind                 // what was moved or promotion
+	Capture bool              // captured another piece
 	EnPassant bool            // was an en passant capture
 	Pawn, Push, Promote bool  // pawn move, 2 space push, promotion
-	Kind Kind                 // what was moved or promotion
 }
 
-// Castle availability is a 4-bit nibble with 2 bits for white
-// and 2 bits for black. To test the availability of a particular
+// Castle availability is an 8-bit mask with a nibble for white
+// and a nibble for black. To test the availability of a particular
 // castle move for a player, test (Side << (Color << 2)).
 
 const (
@@ -35,24 +38,43 @@ const (
 )
 
 func NewGame() *Game {
-	g := new(Game)
+	return NewVariantGame(Standard{})
+}
 
-	// setup the new board
-	g.Position.New()
+func NewVariantGame(v Variant) *Game {
+	g := new(Game)
 
 	// initial state for a new game
 	g.Turn = White
-	g.King[White] = Tile(0, 4)
-	g.King[Black] = Tile(7, 4)
+	g.King[White] = -1
+	g.King[Black] = -1
 	g.EnPassant = -1
-	g.Castles = 15
 	g.HalfMove = 0
 	g.Move = 1
+	g.Variant = v
+
+	// setup the board and castling for the variant
+	v.Setup(g)
+	g.SyncBitboards()
 
 	return g
 }
 
+func (g *Game) SyncBitboards() {
+	g.Bits = g.Position.Bitboards()
+}
+
+func (g *Game) RemovePiece(tile int) {
+	g.Position.Remove(tile)
+	g.Bits.Remove(Square(tile))
+}
+
 func (g *Game) PerformMove(move *Move) {
+	x := g.Position.Piece(move.Dest)
+
+	// keep the bitboards in step with the board
+	g.Bits.Perform(g.Turn, move)
+
 	// check for a castle move
 	if move.Castle != 0 {
 		rank := BackRank[g.Turn]
@@ -85,21 +107,17 @@ func (g *Game) PerformMove(move *Move) {
 				case move.Push:
 					g.EnPassant = enPassant
 					break
-				case move.Promote:
-					g.Position.Place(move.Dest, g.Turn, move.Kind)
-					break
 			}
-		}
 
-		// moving the rooks disables castling
-		switch move.Origin {
-			case Tile(BackRank[g.Turn], 0):
-				g.DisableCastle(Queenside)
-				break
-			case Tile(BackRank[g.Turn], 7):
-				g.DisableCastle(Kingside)
-				break
+			// replace the pawn with the promoted piece
+			if move.Promote {
+				g.Position.Place(move.Dest, g.Turn, move.Kind)
+			}
 		}
+
+		// moving or capturing rooks disables castling
+		g.RevokeCastles(move.Origin)
+		g.RevokeCastles(move.Dest)
 	}
 
 	// disable en passant unless a pawn was pushed
@@ -108,26 +126,151 @@ func (g *Game) PerformMove(move *Move) {
 	}
 
 	// update the king's position if moved
-	if move.Kind == King {
+	if move.Kind == King && move.Promote == false {
 		g.King[g.Turn] = move.Dest
 
 		// moving the king disabled all castling
 		g.DisableCastle(Kingside | Queenside)
 	}
 
+	// a captured king (non-royal variants) is no longer tracked
+	if x != nil && x.Kind == King && g.King[x.Color] == move.Dest {
+		g.King[x.Color] = -1
+	}
+
 	// record the move and switch whose turn it is
 	if g.Turn = g.Turn.Opponent(); g.Turn == White {
 		g.Move++
 	}
 
 	// update the half move counter
-	if move.Pawn == true {
+	if move.Pawn || move.Capture {
 		g.HalfMove = 0
 	} else {
 		g.HalfMove++
 	}
+
+	// apply any variant side effects
+	g.rules().PerformMove(g, move)
 }
 
 func (g *Game) DisableCastle(side int) {
 	g.Castles &= ^(side << uint(g.Turn << 2))
 }
+
+func (g *Game) RevokeCastles(tile int) {
+	for _, c := range [2]Color{ White, Black } {
+		rank := BackRank[c]
+
+		switch tile {
+			case Tile(rank, 0):
+				g.Castles &= ^(Queenside << uint(c << 2))
+				break
+			case Tile(rank, 4):
+				g.Castles &= ^((Kingside | Queenside) << uint(c << 2))
+				break
+			case Tile(rank, 7):
+				g.Castles &= ^(Kingside << uint(c << 2))
+				break
+		}
+	}
+}
+
+func (g *Game) Outcome() Outcome {
+	if outcome := g.rules().End(g); outcome != InProgress {
+		return outcome
+	}
+
+	var list MoveList
+
+	// the game continues as long as there are legal moves
+	if g.legalMoves(&list); list.Len > 0 {
+		return InProgress
+	}
+
+	return g.rules().NoMoves(g)
+}
+
+// VariantOutcome is the result of the variant's own ways of ending the
+// game, like the third check or a king on the hill. Unlike Outcome it
+// doesn't generate moves to look for mate or stalemate, so it's cheap.
+func (g *Game) VariantOutcome() Outcome {
+	return g.rules().End(g)
+}
+
+// the dark squares, a1 is dark
+const darkSquares Bitboard = 0xAA55AA55AA55AA55
+
+// InsufficientMaterial is true when neither player can possibly mate:
+// only kings are left with a single knight or bishop, or bishops all
+// on squares of the same color. Variants win in other ways, so it's
+// only ever true in standard chess.
+func (g *Game) InsufficientMaterial() bool {
+	if _, ok := g.rules().(Standard); ok == false {
+		return false
+	}
+
+	var bishops, knights Bitboard
+
+	for _, c := range [2]Color{ White, Black } {
+		if g.Bits.Pieces[c][Pawn] | g.Bits.Pieces[c][Rook] | g.Bits.Pieces[c][Queen] != 0 {
+			return false
+		}
+
+		bishops |= g.Bits.Pieces[c][Bishop]
+		knights |= g.Bits.Pieces[c][Knight]
+	}
+
+	if (bishops | knights).Count() <= 1 {
+		return true
+	}
+
+	return knights == 0 && (bishops & darkSquares == 0 || bishops & ^darkSquares == 0)
+}
+
+// CanMate is false when a player has too little material to ever mate,
+// whatever the opponent plays: a lone king, a knight against nothing
+// but queens, or bishops on one color against nothing that could
+// block the other. A player who flags only loses if the opponent can
+// mate. In variants every player can win.
+func (g *Game) CanMate(c Color) bool {
+	if _, ok := g.rules().(Standard); ok == false {
+		return true
+	}
+
+	ours := &g.Bits.Pieces[c]
+	theirs := &g.Bits.Pieces[c.Opponent()]
+
+	if ours[Pawn] | ours[Rook] | ours[Queen] != 0 {
+		return true
+	}
+
+	minors := ours[Bishop] | ours[Knight]
+
+	switch {
+		case minors == 0:
+			return false
+		case ours[Knight] != 0:
+			return minors.Count() > 1 || theirs[Pawn] | theirs[Bishop] | theirs[Knight] | theirs[Rook] != 0
+	}
+
+	// bishops on both colors can mate, on one only with a blocker
+	if minors & darkSquares != 0 && minors & ^darkSquares != 0 {
+		return true
+	}
+
+	other := darkSquares
+
+	if minors & darkSquares != 0 {
+		other = ^darkSquares
+	}
+
+	return theirs[Pawn] | theirs[Knight] != 0 || theirs[Bishop] & other != 0
+}
+
+func (g *Game) rules() Variant {
+	if g.Variant == nil {
+		return Standard{}
+	}
+	return g.Variant
+}
@@ -0,0 +1,48 @@
+package chess
+
+// more than enough for any legal position (the record is 218)
+const MaxMoves = 256
+
+// A MoveList is a fixed size buffer of moves that can be reused
+// between calls to GenerateMoves without allocating.
+type MoveList struct {
+	Moves [MaxMoves]Move      // generated moves
+	Len int                   // number of moves in the list
+}
+
+func (list *MoveList) Clear() {
+	list.Len = 0
+}
+
+func (list *MoveList) Add(move Move) {
+	list.Moves[list.Len] = move
+	list.Len++
+}
+
+func (list *MoveList) Slice() []Move {
+	return list.Moves[:list.Len]
+}
+
+// Filter keeps only the moves matching a predicate, in order.
+func (list *MoveList) Filter(keep func(move *Move) bool) {
+	n := 0
+
+	for i := 0; i < list.Len; i++ {
+		if keep(&list.Moves[i]) {
+			list.Moves[n] = list.Moves[i]
+			n++
+		}
+	}
+
+	list.Len = n
+}
+
+func (list *MoveList) Contains(keep func(move *Move) bool) bool {
+	for i := 0; i < list.Len; i++ {
+		if keep(&list.Moves[i]) {
+			return true
+		}
+	}
+
+	return false
+}
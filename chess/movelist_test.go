@@ -0,0 +1,78 @@
+package chess_test
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import "testing"
+
+// an opening, a middlegame full of tactics and an endgame
+var benchFENs = []string{
+	start,
+	kiwipete,
+	"8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1",
+}
+
+func benchGames(b *testing.B) []*chess.Game {
+	games := make([]*chess.Game, len(benchFENs))
+
+	for i, s := range benchFENs {
+		if games[i] = fen.Parse(s); games[i] == nil {
+			b.Fatalf("invalid FEN %s", s)
+		}
+	}
+
+	return games
+}
+
+// CollectMoves allocates a slice of moves every call
+func BenchmarkCollectMoves(b *testing.B) {
+	games := benchGames(b)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, g := range games {
+			g.CollectMoves()
+		}
+	}
+}
+
+// GenerateMoves reuses the same list
+func BenchmarkGenerateMoves(b *testing.B) {
+	games := benchGames(b)
+
+	var list chess.MoveList
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		for _, g := range games {
+			g.GenerateMoves(&list)
+		}
+	}
+}
+
+func BenchmarkPerft(b *testing.B) {
+	g := fen.Parse(kiwipete)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		g.Perft(3)
+	}
+}
+
+// generating moves into a list doesn't allocate at all
+func TestGenerateMovesAllocs(t *testing.T) {
+	var list chess.MoveList
+
+	for _, s := range append(benchFENs, stageFENs...) {
+		g := fen.Parse(s)
+
+		if n := testing.AllocsPerRun(100, func() { g.GenerateMoves(&list) }); n != 0 {
+			t.Errorf("%s: %v allocations, want 0", s, n)
+		}
+	}
+}
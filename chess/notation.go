@@ -6,6 +6,15 @@ func TileNotation(tile int) string {
 	return fmt.Sprintf("%c%d", byte('a') + byte(File(tile)), 1 + Rank(tile))
 }
 
+// ParseTile reads a square such as e4, returning -1 if it isn't one.
+func ParseTile(s string) int {
+	if len(s) != 2 || s[0] < 'a' || s[0] > 'h' || s[1] < '1' || s[1] > '8' {
+		return -1
+	}
+
+	return Tile(int(s[1] - '1'), int(s[0] - 'a'))
+}
+
 func (move *Move) LongNotation() string {
 	switch move.Castle {
 		case Kingside: return "O-O"
@@ -37,3 +46,113 @@ func (move *Move) Parse(notation string) bool {
 
 	return true
 }
+
+func (move *Move) UCI() string {
+	s := TileNotation(move.Origin) + TileNotation(move.Dest)
+
+	if move.Promote {
+		s += string(PieceRunes[Black][move.Kind])
+	}
+
+	return s
+}
+
+// SAN returns the short algebraic notation of a legal move, with
+// only as much of the origin as needed to tell it apart from other
+// moves, and suffixed with check or mate.
+func (g *Game) SAN(move *Move) string {
+	var list MoveList
+	var s string
+
+	// generate the moves to disambiguate against
+	g.GenerateMoves(&list)
+
+	switch {
+		case move.Castle == Kingside:
+			s = "O-O"
+			break
+		case move.Castle == Queenside:
+			s = "O-O-O"
+			break
+		case move.Pawn:
+			if move.Capture {
+				s = TileNotation(move.Origin)[:1] + "x"
+			}
+
+			s += TileNotation(move.Dest)
+
+			if move.Promote {
+				s += "=" + string(PieceRunes[White][move.Kind])
+			}
+			break
+		default:
+			file, rank, ambiguous := false, false, false
+
+			for _, other := range list.Slice() {
+				if other.Pawn || other.Kind != move.Kind || other.Dest != move.Dest || other.Origin == move.Origin {
+					continue
+				}
+
+				ambiguous = true
+
+				// any other piece on the same file or rank
+				file = file || File(other.Origin) == File(move.Origin)
+				rank = rank || Rank(move.Origin) == Rank(other.Origin)
+			}
+
+			origin := TileNotation(move.Origin)
+
+			s = string(PieceRunes[White][move.Kind])
+
+			switch {
+				case ambiguous && file && rank:
+					s += origin
+					break
+				case ambiguous && file:
+					s += origin[1:]
+					break
+				case ambiguous:
+					s += origin[:1]
+					break
+			}
+
+			if move.Capture {
+				s += "x"
+			}
+
+			s += TileNotation(move.Dest)
+			break
+	}
+
+	// play the move to see if it checks or mates
+	after := *g
+	after.PerformMove(move)
+
+	if king := after.King[after.Turn]; king >= 0 && after.InCheck(king) {
+		if after.GenerateMoves(&list); list.Len == 0 {
+			s += "#"
+		} else {
+			s += "+"
+		}
+	}
+
+	return s
+}
+
+func (g *Game) ParseUCI(s string) *Move {
+	var list MoveList
+
+	if len(s) < 4 || len(s) > 5 {
+		return nil
+	}
+
+	g.GenerateMoves(&list)
+
+	for _, move := range list.Slice() {
+		if move.UCI() == s {
+			return &move
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package chess
+
+// Perft counts the leaf nodes of the legal move tree to the given
+// depth. The counts are well known for many positions and variants,
+// which makes them the reference for validating move generation.
+func (g *Game) Perft(depth int) int {
+	if depth == 0 {
+		return 1
+	}
+
+	// one reusable move list per ply
+	return g.perft(make([]MoveList, depth))
+}
+
+func (g *Game) perft(lists []MoveList) int {
+	list := &lists[0]
+
+	// bulk count the last ply
+	if g.GenerateMoves(list); len(lists) == 1 {
+		return list.Len
+	}
+
+	nodes := 0
+
+	for i := 0; i < list.Len; i++ {
+		child := *g
+		child.PerformMove(&list.Moves[i])
+
+		nodes += child.perft(lists[1:])
+	}
+
+	return nodes
+}
@@ -0,0 +1,179 @@
+package chess_test
+
+import (
+	"../chess"
+	"../fen"
+	"../pgn"
+)
+
+import (
+	"reflect"
+	"testing"
+)
+
+const start = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+const kiwipete = "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 0 1"
+
+type perftCase struct {
+	name string
+	variant chess.Variant
+	fen string
+	nodes []int               // by depth, from 1
+}
+
+// reference counts from the chess programming wiki and, for the
+// variants, the perft suites shared by lichess and python-chess
+var perftCases = []perftCase{
+	{ "startpos", chess.Standard{}, start, []int{ 20, 400, 8902, 197281, 4865609 } },
+	{ "kiwipete", chess.Standard{}, kiwipete, []int{ 48, 2039, 97862, 4085603 } },
+	{ "position 3", chess.Standard{}, "8/2p5/3p4/KP5r/1R3p1k/8/4P1P1/8 w - - 0 1", []int{ 14, 191, 2812, 43238, 674624 } },
+	{ "position 4", chess.Standard{}, "r3k2r/Pppp1ppp/1b3nbN/nP6/BBP1P3/q4N2/Pp1P2PP/R2Q1RK1 w kq - 0 1", []int{ 6, 264, 9467, 422333 } },
+	{ "position 5", chess.Standard{}, "rnbq1k1r/pp1Pbppp/2p5/8/2B5/8/PPP1NnPP/RNBQK2R w KQ - 1 8", []int{ 44, 1486, 62379, 2103487 } },
+	{ "position 6", chess.Standard{}, "r4rk1/1pp1qppp/p1np1n2/2b1p1B1/2B1P1b1/P1NP1N2/1PP1QPPP/R4RK1 w - - 0 10", []int{ 46, 2079, 89890, 3894594 } },
+
+	{ "three-check startpos", chess.ThreeCheck{}, start, []int{ 20, 400, 8902, 197281 } },
+	{ "three-check kiwipete", chess.ThreeCheck{}, "r3k2r/p1ppqpb1/bn2pnp1/3PN3/1p2P3/2N2Q1p/PPPBBPPP/R3K2R w KQkq - 1+1 0 1", []int{ 48, 2039, 97848, 4081798 } },
+
+	// no king can reach the center within four plies of either position
+	{ "king of the hill startpos", chess.KingOfTheHill{}, start, []int{ 20, 400, 8902, 197281 } },
+	{ "king of the hill kiwipete", chess.KingOfTheHill{}, kiwipete, []int{ 48, 2039, 97862, 4085603 } },
+
+	// two of the white king's eight moves reach the center and end the game
+	{ "king of the hill center", chess.KingOfTheHill{}, "4k3/8/8/8/8/4K3/8/8 w - - 0 1", []int{ 8, 30 } },
+
+	{ "atomic startpos", chess.Atomic{}, start, []int{ 20, 400, 8902, 197326 } },
+	{ "atomic programfox 1", chess.Atomic{}, "rn2kb1r/1pp1p2p/p2q1pp1/3P4/2P3b1/4PN2/PP3PPP/R2QKB1R b KQkq - 0 1", []int{ 40, 1238, 45237 } },
+	{ "atomic programfox 2", chess.Atomic{}, "rn1qkb1r/p5pp/2p5/3p4/N3P3/5P2/PPP4P/R1BQK3 w Qkq - 0 1", []int{ 28, 833, 23353 } },
+
+	{ "antichess startpos", chess.Antichess{}, "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1", []int{ 20, 400, 8067, 153299 } },
+	{ "antichess a-pawn vs b-pawn", chess.Antichess{}, "8/1p6/8/8/8/8/P7/8 w - - 0 1", []int{ 2, 4, 4, 3, 1, 0 } },
+
+	{ "horde startpos", chess.Horde{}, "rnbqkbnr/pppppppp/8/1PP2PP1/PPPPPPPP/PPPPPPPP/PPPPPPPP/PPPPPPPP w kq - 0 1", []int{ 8, 128, 1274, 23310 } },
+	{ "horde open flank", chess.Horde{}, "4k3/pp4q1/3P2p1/8/P3PP2/PPP2r2/PPP5/PPPP4 b - - 0 1", []int{ 30, 241, 6633, 56539 } },
+	{ "horde en passant", chess.Horde{}, "k7/5p2/4p2P/3p2P1/2p2P2/1p2P2P/p2P2P1/2P2P2 w - - 0 1", []int{ 13, 172, 2205, 33781 } },
+
+	{ "racing kings startpos", chess.RacingKings{}, "8/8/8/8/8/8/krbnNBRK/qrbnNBRQ w - - 0 1", []int{ 21, 421, 11264, 296242 } },
+	{ "racing kings occupied goal", chess.RacingKings{}, "4brn1/2K2k2/8/8/8/8/8/8 w - - 0 1", []int{ 6, 33, 178, 3151 } },
+}
+
+func TestPerft(t *testing.T) {
+	for _, c := range perftCases {
+		t.Run(c.name, func(t *testing.T) {
+			g := fen.ParseVariant(c.fen, c.variant)
+
+			if g == nil {
+				t.Fatalf("invalid FEN %s", c.fen)
+			}
+
+			for i, want := range c.nodes {
+				// the deepest counts take seconds
+				if testing.Short() && want > 1000000 {
+					break
+				}
+
+				if got := g.Perft(i + 1); got != want {
+					t.Errorf("perft(%d) = %d, want %d", i + 1, got, want)
+				}
+			}
+		})
+	}
+}
+
+// the setups of the variants match the reference FENs
+func TestVariantSetup(t *testing.T) {
+	setups := map[string]string{
+		"Standard": start,
+		"Antichess": "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1",
+		"Horde": "rnbqkbnr/pppppppp/8/1PP2PP1/PPPPPPPP/PPPPPPPP/PPPPPPPP/PPPPPPPP w kq - 0 1",
+		"Racing Kings": "8/8/8/8/8/8/krbnNBRK/qrbnNBRQ w - - 0 1",
+	}
+
+	for name, want := range setups {
+		g := chess.NewVariantGame(chess.VariantByName(name))
+		w := fen.Parse(want)
+
+		if reflect.DeepEqual(g.Position, w.Position) == false || g.Castles != w.Castles {
+			t.Errorf("%s doesn't start as %s", name, want)
+		}
+	}
+}
+
+func TestVariantByName(t *testing.T) {
+	names := map[string]chess.Variant{
+		"": chess.Standard{},
+		"From Position": chess.Standard{},
+		"Three-check": chess.ThreeCheck{},
+		"3-check": chess.ThreeCheck{},
+		"King of the Hill": chess.KingOfTheHill{},
+		"koth": chess.KingOfTheHill{},
+		"Atomic": chess.Atomic{},
+		"Antichess": chess.Antichess{},
+		"giveaway": chess.Antichess{},
+		"Horde": chess.Horde{},
+		"Racing Kings": chess.RacingKings{},
+		"crazyhouse": nil,
+	}
+
+	for name, want := range names {
+		if got := chess.VariantByName(name); got != want {
+			t.Errorf("VariantByName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// the Variant and FEN tags of a game choose its rules and position
+func TestVariantTag(t *testing.T) {
+	game := &pgn.PGN{ Tags: map[string]string{
+		"Variant": "Three-check",
+		"FEN": "rnbqkbnr/ppp2ppp/8/3pp3/4P3/5Q2/PPPP1PPP/RNB1KBNR w KQkq - 0 3 +2+0",
+	} }
+
+	g := game.Setup()
+
+	switch {
+		case g == nil:
+			t.Fatal("no game set up")
+		case reflect.TypeOf(g.Variant) != reflect.TypeOf(chess.ThreeCheck{}):
+			t.Errorf("variant is %s, want Three-check", g.Variant.Name())
+			break
+		case g.Checks != [2]int{ 2, 0 }:
+			t.Errorf("checks are %v, want [2 0]", g.Checks)
+			break
+	}
+
+	game.Tags["Variant"] = "Crazyhouse"
+
+	if game.Setup() != nil {
+		t.Error("unknown variant was set up")
+	}
+}
+
+// three-check counters are checks given after the move number, or
+// checks remaining before it as lichess writes them
+func TestThreeCheckCounter(t *testing.T) {
+	given := fen.Parse(start + " +1+2")
+	remaining := fen.Parse("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 2+1 0 1")
+
+	for _, g := range []*chess.Game{ given, remaining } {
+		switch {
+			case g == nil:
+				t.Fatal("invalid FEN")
+			case reflect.TypeOf(g.Variant) != reflect.TypeOf(chess.ThreeCheck{}):
+				t.Errorf("variant is %s, want Three-check", g.Variant.Name())
+				break
+			case g.Checks != [2]int{ 1, 2 }:
+				t.Errorf("checks are %v, want [1 2]", g.Checks)
+				break
+		}
+	}
+
+	if got := fen.Format(given); got != start + " +1+2" {
+		t.Errorf("formatted as %s", got)
+	}
+
+	for _, bad := range []string{ start + " +4+0", start + " +1", "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - x+1 0 1" } {
+		if fen.Parse(bad) != nil {
+			t.Errorf("parsed %s", bad)
+		}
+	}
+}
@@ -31,11 +31,14 @@ var PieceDelta = [6][]int{
 	[]int{ 16, -16 },
 	[]int{ -15, 15, -17, 17 },
 	[]int{ -31, -33, -14, 18, -18, 14, 31, 33 },
-	[]int{ -1, 1, -16, 16, -17, 15, -15, 17 },
 	[]int{ -1, 1, -16, 16 },
 	[]int{ -1, 1, -16, 16, -17, 15, -15, 17 },
+	[]int{ -1, 1, -16, 16, -17, 15, -15, 17 },
 }
 
+// material values in centipawns
+var PieceValue = [6]int{ 100, 330, 320, 500, 20000, 900 }
+
 func (p *Piece) Rune() rune {
 	if p != nil {
 		return PieceRunes[p.Color][p.Kind]
@@ -0,0 +1,129 @@
+package chess
+
+// SEE is the static exchange evaluation of a move: the material the
+// player to move wins (or loses) after every capture and recapture
+// on the destination tile, each side capturing with their least
+// valuable piece and free to stop whenever continuing loses. Sliders
+// behind other attackers join in as the pieces in front capture.
+func (g *Game) SEE(move *Move) int {
+	if move.Castle != 0 {
+		return 0
+	}
+
+	origin, dest := Square(move.Origin), Square(move.Dest)
+	occ := g.Bits.Occupied &^ SquareBit(origin)
+
+	gain := 0
+	attacker := g.kindAt(origin)
+
+	if move.Capture {
+		if move.EnPassant {
+			occ &^= TileBit(move.Dest + PieceDelta[Pawn][g.Turn.Opponent()])
+			gain = PieceValue[Pawn]
+		} else {
+			gain = PieceValue[g.kindAt(dest)]
+		}
+	}
+
+	// the pawn is replaced by what it promotes to
+	if move.Promote {
+		gain += PieceValue[move.Kind] - PieceValue[Pawn]
+		attacker = move.Kind
+	}
+
+	return g.exchange(dest, g.Turn.Opponent(), attacker, gain, occ)
+}
+
+func (g *Game) SEEGreaterEqual(move *Move, threshold int) bool {
+	return g.SEE(move) >= threshold
+}
+
+// SEETile is what a color wins by capturing the piece on a tile and
+// continuing the exchange, or zero if it shouldn't capture at all.
+// A piece is hanging when its opponent's SEETile is positive.
+func (g *Game) SEETile(tile int, color Color) int {
+	sq := Square(tile)
+
+	if g.Bits.Colors[color.Opponent()].Has(sq) == false {
+		return 0
+	}
+
+	occ := g.Bits.Occupied
+
+	// start by capturing with the least valuable attacker
+	from, attacker, ok := g.leastValuable(sq, color, occ)
+
+	if ok == false {
+		return 0
+	}
+
+	gain := g.exchange(sq, color.Opponent(), attacker, PieceValue[g.kindAt(sq)], occ &^ SquareBit(from))
+
+	if gain < 0 {
+		return 0
+	}
+
+	return gain
+}
+
+// exchange resolves the captures on a square after the first one,
+// which gained the first entry and left attacker on the square.
+func (g *Game) exchange(sq int, side Color, attacker Kind, gain int, occ Bitboard) int {
+	var gains [32]int
+
+	gains[0] = gain
+	d := 0
+
+	for d < len(gains) - 1 {
+		from, kind, ok := g.leastValuable(sq, side, occ)
+
+		if ok == false {
+			break
+		}
+
+		// the king can't capture into a defended square
+		if kind == King && g.Bits.Attackers(sq, side.Opponent(), occ &^ SquareBit(from)) != 0 {
+			break
+		}
+
+		// capture the last attacker, the next one stands on the square
+		d++
+		gains[d] = PieceValue[attacker] - gains[d - 1]
+		attacker = kind
+		occ &^= SquareBit(from)
+		side = side.Opponent()
+	}
+
+	// either side may stop capturing when it would lose
+	for ; d > 0; d-- {
+		if -gains[d] < gains[d - 1] {
+			gains[d - 1] = -gains[d]
+		}
+	}
+
+	return gains[0]
+}
+
+func (g *Game) leastValuable(sq int, side Color, occ Bitboard) (int, Kind, bool) {
+	attackers := g.Bits.Attackers(sq, side, occ)
+
+	for _, kind := range [...]Kind{ Pawn, Knight, Bishop, Rook, Queen, King } {
+		if pieces := attackers & g.Bits.Pieces[side][kind]; pieces != 0 {
+			return pieces.First(), kind, true
+		}
+	}
+
+	return 0, Pawn, false
+}
+
+func (g *Game) kindAt(sq int) Kind {
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			if g.Bits.Pieces[c][kind].Has(sq) {
+				return kind
+			}
+		}
+	}
+
+	return Pawn
+}
@@ -0,0 +1,93 @@
+package chess
+
+// A Stage selects a subset of the legal moves to generate.
+type Stage int
+
+const (
+	AllMoves Stage = iota     // every legal move
+	Captures                  // captures and promotions
+	Quiets                    // everything else
+	Evasions                  // all legal moves, but only when in check
+	QuietChecks               // quiet moves that give check
+)
+
+// Captures and Quiets partition the legal moves, QuietChecks are a
+// subset of Quiets, and Evasions are either every legal move or none.
+
+var promotionRanks = [2]Bitboard{
+	0xFF << 56,
+	0xFF,
+}
+
+func (g *Game) GenerateStage(list *MoveList, stage Stage) {
+	if g.rules().End(g) != InProgress {
+		list.Clear()
+		return
+	}
+
+	g.stageMoves(list, stage)
+
+	// let the variant trim the list of legal moves
+	g.rules().FilterMoves(g, list)
+}
+
+func (g *Game) stageMoves(list *MoveList, stage Stage) {
+	empty := ^g.Bits.Occupied
+	opp := g.Bits.Colors[g.Turn.Opponent()]
+
+	// limit move generation to the destinations of the stage
+	switch stage {
+		case AllMoves:
+			g.PseudoLegalMoves(list, empty | opp)
+			break
+		case Captures:
+			g.PseudoLegalMoves(list, opp | (empty & promotionRanks[g.Turn]))
+			break
+		case Quiets, QuietChecks:
+			g.PseudoLegalMoves(list, empty)
+			break
+		case Evasions:
+			if king := g.King[g.Turn]; king >= 0 && g.InCheck(king) {
+				g.PseudoLegalMoves(list, empty | opp)
+			} else {
+				list.Clear()
+			}
+			break
+	}
+
+	// filter legal moves from the pseudo legal ones
+	g.rules().Legal(g, list)
+
+	// the destinations are close, now match the stage exactly
+	switch stage {
+		case Captures:
+			list.Filter(func(move *Move) bool {
+				return move.Capture || move.Promote
+			})
+			break
+		case Quiets:
+			list.Filter(func(move *Move) bool {
+				return !move.Capture && !move.Promote
+			})
+			break
+		case QuietChecks:
+			list.Filter(func(move *Move) bool {
+				return !move.Capture && !move.Promote && g.GivesCheck(move)
+			})
+			break
+	}
+}
+
+func (g *Game) GivesCheck(move *Move) bool {
+	king := g.King[g.Turn.Opponent()]
+
+	if king < 0 {
+		return false
+	}
+
+	// make the move on a copy of the bitboards
+	bits := g.Bits
+	bits.Perform(g.Turn, move)
+
+	return bits.Attacked(Square(king), g.Turn)
+}
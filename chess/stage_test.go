@@ -0,0 +1,139 @@
+package chess_test
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import "testing"
+
+// positions with checks, pins, promotions, en passant and castling
+var stageFENs = []string{
+	"4k3/8/8/8/8/8/4r3/R3K2R w KQ - 0 1",
+	"rnb1kbnr/pppp1ppp/8/4p3/6Pq/5P2/PPPPP2P/RNBQKBNR w KQkq - 1 3",
+	"rnbqkbnr/ppp2ppp/8/1B1pp3/4P3/8/PPPP1PPP/RNBQK1NR b KQkq - 1 3",
+	"8/8/8/2k5/3Pp3/8/8/4K2R b K d3 0 1",
+	"8/8/3k4/8/2pP4/8/B7/4K3 b - d3 0 1",
+	"r3k2r/1P6/8/8/8/8/6p1/R3K2R w KQkq - 0 1",
+	"3r4/8/8/8/8/8/2PPP3/r2K4 w - - 0 1",
+	"8/P1k5/K7/8/8/8/8/8 w - - 0 1",
+	"4k3/8/8/8/1b6/8/3N4/4K3 w - - 0 1",
+	"6k1/5ppp/8/8/8/8/5PPP/3R2K1 w - - 0 1",
+	"r1bqkb1r/pppp1ppp/2n2n2/4p2Q/2B1P3/8/PPPP1PPP/RNB1K1NR w KQkq - 4 4",
+}
+
+// key of a move, without the check flags added once it's played
+type moveKey struct {
+	origin, dest, castle int
+	kind chess.Kind
+}
+
+func keys(t *testing.T, list *chess.MoveList) map[moveKey]bool {
+	set := make(map[moveKey]bool)
+
+	for _, m := range list.Slice() {
+		k := moveKey{ m.Origin, m.Dest, m.Castle, m.Kind }
+
+		if set[k] {
+			t.Errorf("%v generated twice", m)
+		}
+
+		set[k] = true
+	}
+
+	return set
+}
+
+// checkStages tests the stages of a position, and of every position
+// reached from it in a few plies. Errors name the root position.
+func checkStages(t *testing.T, root string, g *chess.Game, depth int) {
+	var all, captures, quiets, evasions, checks chess.MoveList
+
+	g.GenerateMoves(&all)
+	g.GenerateStage(&captures, chess.Captures)
+	g.GenerateStage(&quiets, chess.Quiets)
+	g.GenerateStage(&evasions, chess.Evasions)
+	g.GenerateStage(&checks, chess.QuietChecks)
+
+	want := keys(t, &all)
+	capture, quiet := keys(t, &captures), keys(t, &quiets)
+
+	// captures and quiets partition the legal moves
+	for k := range capture {
+		if quiet[k] {
+			t.Errorf("%s: %v is a capture and a quiet", root, k)
+		}
+	}
+
+	union := make(map[moveKey]bool)
+
+	for _, set := range []map[moveKey]bool{ capture, quiet } {
+		for k := range set {
+			union[k] = true
+		}
+	}
+
+	if !sameKeys(union, want) {
+		t.Errorf("%s: captures and quiets are %d moves, want %d", root, len(union), len(want))
+	}
+
+	// evasions are every legal move in check, otherwise none
+	inCheck := g.King[g.Turn] >= 0 && g.InCheck(g.King[g.Turn])
+
+	if evade := keys(t, &evasions); inCheck && !sameKeys(evade, want) || !inCheck && len(evade) > 0 {
+		t.Errorf("%s: %d evasions of %d moves, in check %v", root, len(evade), len(want), inCheck)
+	}
+
+	// quiet checks are quiet and do check
+	for i := range checks.Slice() {
+		m := &checks.Moves[i]
+		k := moveKey{ m.Origin, m.Dest, m.Castle, m.Kind }
+
+		child := *g
+		child.PerformMove(m)
+
+		if quiet[k] == false || child.King[child.Turn] < 0 || child.InCheck(child.King[child.Turn]) == false {
+			t.Errorf("%s: %v isn't a quiet check", root, k)
+		}
+	}
+
+	if depth > 0 {
+		for i := range all.Slice() {
+			child := *g
+			child.PerformMove(&all.Moves[i])
+
+			checkStages(t, root, &child, depth - 1)
+		}
+	}
+}
+
+func sameKeys(a, b map[moveKey]bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for k := range a {
+		if b[k] == false {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestStages(t *testing.T) {
+	for _, s := range stageFENs {
+		g := fen.Parse(s)
+
+		if g == nil {
+			t.Fatalf("invalid FEN %s", s)
+		}
+
+		checkStages(t, s, g, 2)
+	}
+
+	// the perft positions cover the variants too
+	for _, c := range perftCases {
+		checkStages(t, c.fen, fen.ParseVariant(c.fen, c.variant), 1)
+	}
+}
@@ -0,0 +1,130 @@
+package chess
+
+import "strings"
+
+type Outcome int
+
+const (
+	InProgress Outcome = iota
+	Draw
+	WhiteWins
+	BlackWins
+)
+
+// A Variant is consulted by the Game for everything that can differ
+// from the rules of standard chess: the starting setup, which pseudo
+// legal moves are legal, side effects of moves and how a game ends.
+// Variants embed Standard and override only what they change.
+type Variant interface {
+	Name() string                                // PGN Variant tag
+	Setup(g *Game)                               // initial position
+	Promotions() []Kind                          // pawn promotion choices
+	PawnPush(g *Game, tile int) bool             // pawn can move 2 tiles
+	Legal(g *Game, list *MoveList)               // pseudo legal filter
+	FilterMoves(g *Game, list *MoveList)         // legal move list filter
+	PerformMove(g *Game, move *Move)             // after move side effects
+	End(g *Game) Outcome                         // variant specific ending
+	NoMoves(g *Game) Outcome                     // result without any moves
+}
+
+type Standard struct{}
+
+// all the available variants by PGN Variant tag
+var Variants = []Variant{
+	Standard{},
+	ThreeCheck{},
+	KingOfTheHill{},
+	Atomic{},
+	Antichess{},
+	Horde{},
+	RacingKings{},
+}
+
+// alternate names found in PGN Variant tags
+var variantAliases = map[string]string{
+	"":              "standard",
+	"chess":         "standard",
+	"from position": "standard",
+	"3-check":       "three-check",
+	"threecheck":    "three-check",
+	"koth":          "king of the hill",
+	"kingofthehill": "king of the hill",
+	"giveaway":      "antichess",
+	"racingkings":   "racing kings",
+}
+
+func Win(c Color) Outcome {
+	if c == White {
+		return WhiteWins
+	}
+	return BlackWins
+}
+
+func VariantByName(name string) Variant {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if alias, ok := variantAliases[name]; ok {
+		name = alias
+	}
+
+	for _, v := range Variants {
+		if strings.ToLower(v.Name()) == name {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// IsStandard is true when the game is played by the rules of standard
+// chess, with no variant.
+func (g *Game) IsStandard() bool {
+	return g.Variant == nil || g.Variant.Name() == "Standard"
+}
+
+func (Standard) Name() string {
+	return "Standard"
+}
+
+func (Standard) Setup(g *Game) {
+	g.Position.New()
+
+	// kings start on the e-file and can castle both ways
+	g.King[White] = Tile(BackRank[White], 4)
+	g.King[Black] = Tile(BackRank[Black], 4)
+	g.Castles = 0x33
+}
+
+// the same slice every call, so generating moves doesn't allocate
+var standardPromotions = []Kind{ Queen, Rook, Bishop, Knight }
+
+func (Standard) Promotions() []Kind {
+	return standardPromotions
+}
+
+func (Standard) PawnPush(g *Game, tile int) bool {
+	return Rank(tile) == PawnRank[g.Turn]
+}
+
+func (Standard) Legal(g *Game, list *MoveList) {
+	g.filterSafe(list)
+}
+
+func (Standard) FilterMoves(g *Game, list *MoveList) {
+}
+
+func (Standard) PerformMove(g *Game, move *Move) {
+}
+
+func (Standard) End(g *Game) Outcome {
+	return InProgress
+}
+
+func (Standard) NoMoves(g *Game) Outcome {
+	if king := g.King[g.Turn]; king >= 0 && g.InCheck(king) {
+		return Win(g.Turn.Opponent())
+	}
+
+	// stalemate
+	return Draw
+}
@@ -0,0 +1,312 @@
+package chess
+
+// Three-check: a player also wins by giving check three times.
+type ThreeCheck struct{ Standard }
+
+// King of the hill: a player also wins by moving their king to
+// one of the four center tiles.
+type KingOfTheHill struct{ Standard }
+
+// Atomic: captures explode, removing the capturing piece and every
+// piece other than pawns around the destination. Exploding the
+// opponent's king wins; kings can't capture.
+type Atomic struct{ Standard }
+
+// Antichess: captures are compulsory, the king is an ordinary piece
+// and the first player to lose all their pieces (or be stalemated)
+// wins.
+type Antichess struct{ Standard }
+
+// Horde: white has 36 pawns and no king, and wins by checkmating
+// black. Black wins by capturing every white piece.
+type Horde struct{ Standard }
+
+// Racing kings: no move may give check, and the first king to reach
+// the back rank wins. If black reaches it right after white, it's a
+// draw.
+type RacingKings struct{ Standard }
+
+var hillTiles = [...]int{
+	Tile(3, 3), Tile(3, 4), Tile(4, 3), Tile(4, 4),
+}
+
+func (ThreeCheck) Name() string {
+	return "Three-check"
+}
+
+func (ThreeCheck) PerformMove(g *Game, move *Move) {
+	if king := g.King[g.Turn]; king >= 0 && g.InCheck(king) {
+		g.Checks[g.Turn.Opponent()]++
+	}
+}
+
+func (ThreeCheck) End(g *Game) Outcome {
+	for _, c := range [2]Color{ White, Black } {
+		if g.Checks[c] >= 3 {
+			return Win(c)
+		}
+	}
+
+	return InProgress
+}
+
+func (KingOfTheHill) Name() string {
+	return "King of the Hill"
+}
+
+func (KingOfTheHill) End(g *Game) Outcome {
+	for _, c := range [2]Color{ White, Black } {
+		for _, tile := range hillTiles {
+			if g.King[c] == tile {
+				return Win(c)
+			}
+		}
+	}
+
+	return InProgress
+}
+
+func (Atomic) Name() string {
+	return "Atomic"
+}
+
+func (Atomic) Legal(g *Game, list *MoveList) {
+	s := g.safety()
+
+	list.Filter(func(move *Move) bool {
+		if move.Castle != 0 {
+			return s.safe(g, move)
+		}
+
+		// the king would explode if it captured
+		if move.Kind == King && move.Capture {
+			return false
+		}
+
+		// make the move on a copy of the game
+		after := *g
+		after.PerformMove(move)
+
+		us := after.King[g.Turn]
+		them := after.King[after.Turn]
+
+		switch {
+			case us < 0:           return false
+			case them < 0:         return true
+			case Touching(us, them): return true
+		}
+
+		return after.Attacked(us, after.Turn) == false
+	})
+}
+
+func (Atomic) PerformMove(g *Game, move *Move) {
+	if move.Capture == false {
+		return
+	}
+
+	// the capturing piece explodes
+	g.RemovePiece(move.Dest)
+
+	// along with everything but pawns around it
+	for _, d := range PieceDelta[King] {
+		tile := move.Dest + d
+
+		if p := g.Position.Piece(tile); p != nil && p.Kind != Pawn {
+			if p.Kind == King {
+				g.King[p.Color] = -1
+			}
+
+			// exploding rooks and kings loses castling
+			g.RemovePiece(tile)
+			g.RevokeCastles(tile)
+		}
+	}
+}
+
+func (Atomic) End(g *Game) Outcome {
+	for _, c := range [2]Color{ White, Black } {
+		if g.King[c] < 0 {
+			return Win(c.Opponent())
+		}
+	}
+
+	return InProgress
+}
+
+func (Atomic) NoMoves(g *Game) Outcome {
+	king := g.King[g.Turn]
+
+	// kings touching can never be in check
+	if Touching(king, g.King[g.Turn.Opponent()]) {
+		return Draw
+	}
+
+	if g.InCheck(king) {
+		return Win(g.Turn.Opponent())
+	}
+
+	return Draw
+}
+
+func (Antichess) Name() string {
+	return "Antichess"
+}
+
+func (Antichess) Setup(g *Game) {
+	Standard{}.Setup(g)
+
+	// there is no castling
+	g.Castles = 0
+}
+
+var antichessPromotions = []Kind{ Queen, Rook, Bishop, Knight, King }
+
+func (Antichess) Promotions() []Kind {
+	return antichessPromotions
+}
+
+// without royal kings every pseudo legal move is legal
+func (Antichess) Legal(g *Game, list *MoveList) {
+}
+
+func (Antichess) FilterMoves(g *Game, list *MoveList) {
+	var captures MoveList
+
+	capture := func(move *Move) bool {
+		return move.Capture
+	}
+
+	// captures are compulsory when available
+	if list.Contains(capture) {
+		list.Filter(capture)
+		return
+	}
+
+	// the list may only be a stage without the captures
+	if g.stageMoves(&captures, Captures); captures.Contains(capture) {
+		list.Clear()
+	}
+}
+
+func (Antichess) NoMoves(g *Game) Outcome {
+	return Win(g.Turn)
+}
+
+func (Horde) Name() string {
+	return "Horde"
+}
+
+func (Horde) Setup(g *Game) {
+	Standard{}.Setup(g)
+
+	// replace white's army with the horde
+	for rank := 0; rank < 4; rank++ {
+		for file := 0; file < 8; file++ {
+			g.Position.Place(Tile(rank, file), White, Pawn)
+		}
+	}
+
+	for _, file := range [...]int{ 1, 2, 5, 6 } {
+		g.Position.Place(Tile(4, file), White, Pawn)
+	}
+
+	// white has no king to castle with
+	g.King[White] = -1
+	g.Castles = 0x30
+}
+
+func (Horde) PawnPush(g *Game, tile int) bool {
+	if g.Turn == White {
+		return Rank(tile) <= PawnRank[White]
+	}
+	return Rank(tile) == PawnRank[Black]
+}
+
+func (Horde) PerformMove(g *Game, move *Move) {
+	if move.Push && Rank(move.Origin) == BackRank[White] {
+		g.EnPassant = -1
+	}
+}
+
+func (Horde) End(g *Game) Outcome {
+	if g.Bits.Colors[White] != 0 {
+		return InProgress
+	}
+
+	return BlackWins
+}
+
+func (RacingKings) Name() string {
+	return "Racing Kings"
+}
+
+func (RacingKings) Setup(g *Game) {
+	back := [...]Kind{ Queen, Rook, Bishop, Knight }
+	front := [...]Kind{ King, Rook, Bishop, Knight }
+
+	// black on the queenside, white mirrored on the kingside
+	for file := 0; file < 4; file++ {
+		g.Position.Place(Tile(0, file), Black, back[file])
+		g.Position.Place(Tile(1, file), Black, front[file])
+		g.Position.Place(Tile(0, 7 - file), White, back[file])
+		g.Position.Place(Tile(1, 7 - file), White, front[file])
+	}
+
+	g.King[White] = Tile(1, 7)
+	g.King[Black] = Tile(1, 0)
+	g.Castles = 0
+}
+
+func (RacingKings) Legal(g *Game, list *MoveList) {
+	s := g.safety()
+
+	// giving check isn't allowed
+	list.Filter(func(move *Move) bool {
+		return s.safe(g, move) && g.GivesCheck(move) == false
+	})
+}
+
+func (RacingKings) End(g *Game) Outcome {
+	white := Rank(g.King[White]) == BackRank[Black]
+	black := Rank(g.King[Black]) == BackRank[Black]
+
+	switch {
+		case white && black:       return Draw
+		case black:                return BlackWins
+		case white && g.Turn == White: return WhiteWins
+		case white:
+			var list MoveList
+
+			// black gets one last move to catch up
+			g.legalMoves(&list)
+
+			if list.Contains(func(move *Move) bool {
+				return move.Kind == King && Rank(move.Dest) == BackRank[Black]
+			}) {
+				return InProgress
+			}
+
+			return WhiteWins
+	}
+
+	return InProgress
+}
+
+func (RacingKings) NoMoves(g *Game) Outcome {
+	return Draw
+}
+
+func Touching(a, b int) bool {
+	if a < 0 || b < 0 {
+		return false
+	}
+
+	for _, d := range PieceDelta[King] {
+		if a + d == b {
+			return true
+		}
+	}
+
+	return false
+}
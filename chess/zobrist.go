@@ -0,0 +1,76 @@
+package chess
+
+// random keys for each part of a position
+var zobristPieces [2][6][64]uint64
+var zobristCastles [256]uint64
+var zobristEnPassant [8]uint64
+var zobristChecks [2][4]uint64
+var zobristBlack uint64
+
+func init() {
+	seed := uint64(0x9E3779B97F4A7C15)
+
+	// splitmix64, so the keys are the same on every run
+	next := func() uint64 {
+		seed += 0x9E3779B97F4A7C15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+		z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+		return z ^ (z >> 31)
+	}
+
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			for sq := 0; sq < 64; sq++ {
+				zobristPieces[c][kind][sq] = next()
+			}
+		}
+
+		for i := range zobristChecks[c] {
+			zobristChecks[c][i] = next()
+		}
+	}
+
+	for i := range zobristCastles {
+		zobristCastles[i] = next()
+	}
+
+	for i := range zobristEnPassant {
+		zobristEnPassant[i] = next()
+	}
+
+	zobristBlack = next()
+}
+
+// Hash returns the Zobrist key of the position: the pieces, player to
+// move, castling and en passant availability, and checks given. Two
+// games with the same key are (almost certainly) the same position.
+func (g *Game) Hash() uint64 {
+	var key uint64
+
+	for c := White; c <= Black; c++ {
+		for kind := Pawn; kind <= Queen; kind++ {
+			for pieces := g.Bits.Pieces[c][kind]; pieces != 0; {
+				key ^= zobristPieces[c][kind][pieces.Pop()]
+			}
+		}
+
+		// three-check counts are part of the position
+		if n := g.Checks[c]; n > 0 {
+			key ^= zobristChecks[c][n & 3]
+		}
+	}
+
+	if g.Turn == Black {
+		key ^= zobristBlack
+	}
+
+	key ^= zobristCastles[g.Castles & 0xFF]
+
+	// en passant only matters when it can be captured
+	if g.EnPassant >= 0 && PawnAttacks[g.Turn.Opponent()][Square(g.EnPassant)] & g.Bits.Pieces[g.Turn][Pawn] != 0 {
+		key ^= zobristEnPassant[File(g.EnPassant)]
+	}
+
+	return key
+}
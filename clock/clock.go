@@ -0,0 +1,161 @@
+package clock
+
+import "../chess"
+
+import "time"
+
+// A Clock keeps the time of both players. Only the clock of the player
+// to move runs, and playing a move switches it to the opponent.
+type Clock struct {
+	Control TimeControl
+	Turn chess.Color          // whose clock is running, or would be
+	Now func() time.Time      // time.Now, unless replaying a game
+	left [2]time.Duration     // time left when the turn started
+	moves [2]int              // moves played by each player
+	start time.Time           // when the running clock was started
+	running bool
+}
+
+func New(tc TimeControl) *Clock {
+	c := &Clock{ Control: tc, Now: time.Now }
+
+	if tc.Timed() {
+		c.left[chess.White] = tc.Stages[0].Time
+		c.left[chess.Black] = tc.Stages[0].Time
+	}
+
+	return c
+}
+
+// Start runs the clock of the player to move.
+func (c *Clock) Start() {
+	if c.running == false {
+		c.start, c.running = c.Now(), true
+	}
+}
+
+// Stop pauses the clock, taking the time used so far from the player
+// to move. A delay starts over when the clock is started again.
+func (c *Clock) Stop() {
+	if c.running {
+		c.left[c.Turn] -= c.spent(c.Now())
+		c.running = false
+	}
+}
+
+// Set the time a player has left, e.g. when resuming a game from the
+// [%clk] comments of its moves.
+func (c *Clock) Set(color chess.Color, left time.Duration) {
+	if c.running && color == c.Turn {
+		c.start = c.Now()
+	}
+
+	c.left[color] = left
+}
+
+// Left is the time a player has now, never below zero.
+func (c *Clock) Left(color chess.Color) time.Duration {
+	left := c.left[color]
+
+	if c.running && color == c.Turn {
+		left -= c.spent(c.Now())
+	}
+
+	if left < 0 {
+		return 0
+	}
+
+	return left
+}
+
+// Flagged is true when the player to move has run out of time.
+func (c *Clock) Flagged() bool {
+	return c.Control.Timed() && c.Left(c.Turn) == 0
+}
+
+// Move ends the turn of the player to move, taking the time used from
+// their clock and adding any increment, delay given back or time for
+// a new stage, then starts the opponent's clock. It returns how long
+// the move took, and false without switching when the player's flag
+// had already fallen.
+func (c *Clock) Move() (time.Duration, bool) {
+	now := c.Now()
+
+	var elapsed time.Duration
+
+	if c.running {
+		elapsed = now.Sub(c.start)
+	}
+
+	if c.Control.Timed() {
+		if c.Flagged() {
+			return elapsed, false
+		}
+
+		stage := c.stage()
+
+		if c.running {
+			c.left[c.Turn] -= c.spent(now)
+		}
+
+		if stage.DelayKind == Bronstein {
+			c.left[c.Turn] += min(elapsed, stage.Delay)
+		}
+
+		c.left[c.Turn] += stage.Increment
+		c.moves[c.Turn]++
+
+		// the time for the next stage comes with the last move of this one
+		if i, reached := c.Control.stage(c.moves[c.Turn]); reached {
+			c.left[c.Turn] += c.Control.Stages[i].Time
+		}
+	}
+
+	c.Turn = c.Turn.Opponent()
+	c.start = now
+
+	return elapsed, true
+}
+
+// Outcome of the game when the player to move has flagged, otherwise
+// still in progress.
+func (c *Clock) Outcome(g *chess.Game) chess.Outcome {
+	if c.Flagged() {
+		return Flag(g, c.Turn)
+	}
+	return chess.InProgress
+}
+
+// Flag is the outcome of a game when a player runs out of time: a loss,
+// or a draw when the opponent couldn't mate by any series of moves.
+func Flag(g *chess.Game, c chess.Color) chess.Outcome {
+	if g.CanMate(c.Opponent()) {
+		return chess.Win(c.Opponent())
+	}
+	return chess.Draw
+}
+
+// the stage the player to move is in
+func (c *Clock) stage() Stage {
+	i, _ := c.Control.stage(c.moves[c.Turn])
+
+	return c.Control.Stages[i]
+}
+
+// spent is the time the running clock has taken from the player to
+// move, which with a simple delay only starts once the delay is over.
+func (c *Clock) spent(now time.Time) time.Duration {
+	elapsed := now.Sub(c.start)
+
+	if c.Control.Timed() == false {
+		return elapsed
+	}
+
+	if stage := c.stage(); stage.DelayKind == Simple {
+		if elapsed -= stage.Delay; elapsed < 0 {
+			return 0
+		}
+	}
+
+	return elapsed
+}
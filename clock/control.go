@@ -0,0 +1,233 @@
+package clock
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUnknown = errors.New("unknown time control")
+	ErrSandclock = errors.New("sandclock time controls aren't supported")
+)
+
+type Delay int
+
+const (
+	NoDelay Delay = iota
+	Simple                    // the clock waits before counting down
+	Bronstein                 // time used is given back, up to the delay
+)
+
+// A Stage is a period of a time control: a number of moves to be
+// played in the time, or the rest of the game if there are none.
+type Stage struct {
+	Moves int
+	Time time.Duration
+	Increment time.Duration   // added after every move (Fischer)
+	Delay time.Duration       // before each move costs time
+	DelayKind Delay
+}
+
+// A TimeControl is the stages of a game, in order. A last stage with a
+// number of moves repeats. No stages means the game isn't timed.
+type TimeControl struct {
+	Stages []Stage
+}
+
+// Common controls: sudden death, increment, delay and a classical
+// control with 90 minutes for 40 moves, 30 for the rest, and 30 seconds
+// per move throughout.
+func SuddenDeath(d time.Duration) TimeControl {
+	return TimeControl{ Stages: []Stage{ { Time: d } } }
+}
+
+func Fischer(d, inc time.Duration) TimeControl {
+	return TimeControl{ Stages: []Stage{ { Time: d, Increment: inc } } }
+}
+
+func Delayed(d, delay time.Duration, kind Delay) TimeControl {
+	return TimeControl{ Stages: []Stage{ { Time: d, Delay: delay, DelayKind: kind } } }
+}
+
+var Classical = TimeControl{
+	Stages: []Stage{
+		{ Moves: 40, Time: 90 * time.Minute, Increment: 30 * time.Second },
+		{ Time: 30 * time.Minute, Increment: 30 * time.Second },
+	},
+}
+
+// Parse reads the TimeControl tag of a PGN file: stages separated by
+// colons, each the seconds for the rest of the game or moves/seconds,
+// with +seconds of increment, e.g. "40/5400+30:1800+30". A "-" is an
+// untimed game. Delays aren't in the standard, they're written like
+// increments with d for a simple delay (300d5) or b for Bronstein,
+// and can follow an increment (300+2d5). Every stage needs some time,
+// and the numbers are plain seconds, without signs or exponents.
+func Parse(s string) (TimeControl, error) {
+	var tc TimeControl
+
+	switch s = strings.TrimSpace(s); s {
+		case "", "?":
+			return tc, ErrUnknown
+		case "-":
+			return tc, nil
+	}
+
+	for _, field := range strings.Split(s, ":") {
+		stage, err := parseStage(field)
+
+		if err != nil {
+			return TimeControl{}, err
+		}
+
+		tc.Stages = append(tc.Stages, stage)
+	}
+
+	return tc, nil
+}
+
+func parseStage(s string) (Stage, error) {
+	var stage Stage
+
+	if strings.HasPrefix(s, "*") {
+		return stage, ErrSandclock
+	}
+
+	if i := strings.Index(s, "/"); i >= 0 {
+		n, err := strconv.Atoi(s[:i])
+
+		if err != nil || n <= 0 {
+			return stage, fmt.Errorf("bad moves in time control %s", s)
+		}
+
+		stage.Moves, s = n, s[i + 1:]
+	}
+
+	// an increment and a delay may both follow the time, e.g. 300+2d5,
+	// so they're taken off the end one at a time
+	field, increment := s, false
+
+	for i := strings.LastIndexAny(s, "+db"); i >= 0; i = strings.LastIndexAny(s, "+db") {
+		d, err := seconds(s[i + 1:])
+
+		if err != nil {
+			return stage, err
+		}
+
+		switch {
+			case s[i] == '+' && increment == false:
+				stage.Increment, increment = d, true
+				break
+			case s[i] == 'd' && stage.DelayKind == NoDelay:
+				stage.Delay, stage.DelayKind = d, Simple
+				break
+			case s[i] == 'b' && stage.DelayKind == NoDelay:
+				stage.Delay, stage.DelayKind = d, Bronstein
+				break
+			default:
+				return stage, fmt.Errorf("more than one increment or delay in time control %s", field)
+		}
+
+		s = s[:i]
+	}
+
+	d, err := seconds(s)
+
+	if err != nil {
+		return stage, err
+	}
+
+	if d <= 0 {
+		return stage, fmt.Errorf("no time in time control %s", field)
+	}
+
+	stage.Time = d
+
+	return stage, nil
+}
+
+// seconds reads a whole or fractional number of seconds, written with
+// only digits and a point, so that signs, exponents, inf and NaN are
+// all errors, and less than a lifetime so the duration can't overflow
+func seconds(s string) (time.Duration, error) {
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || strings.Trim(s, "0123456789.") != "" || f > 1e9 {
+		return 0, fmt.Errorf("bad seconds in time control %s", s)
+	}
+
+	return time.Duration(f * float64(time.Second) + 0.5), nil
+}
+
+// String writes the time control as a PGN TimeControl tag.
+func (tc TimeControl) String() string {
+	if len(tc.Stages) == 0 {
+		return "-"
+	}
+
+	fields := make([]string, len(tc.Stages))
+
+	for i, stage := range tc.Stages {
+		fields[i] = stage.String()
+	}
+
+	return strings.Join(fields, ":")
+}
+
+func (s Stage) String() string {
+	var b strings.Builder
+
+	if s.Moves > 0 {
+		fmt.Fprintf(&b, "%d/", s.Moves)
+	}
+
+	b.WriteString(formatSeconds(s.Time))
+
+	if s.Increment > 0 {
+		b.WriteString("+" + formatSeconds(s.Increment))
+	}
+
+	switch s.DelayKind {
+		case Simple:
+			b.WriteString("d" + formatSeconds(s.Delay))
+			break
+		case Bronstein:
+			b.WriteString("b" + formatSeconds(s.Delay))
+			break
+	}
+
+	return b.String()
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// Timed is true if the game is played with a clock.
+func (tc TimeControl) Timed() bool {
+	return len(tc.Stages) > 0
+}
+
+// stage is the stage a player is in after a number of their moves,
+// and whether they reached it with the last of them.
+func (tc TimeControl) stage(moves int) (int, bool) {
+	i, played := 0, 0
+
+	for {
+		s := tc.Stages[i]
+
+		if s.Moves == 0 || played + s.Moves > moves {
+			return i, played == moves && moves > 0
+		}
+
+		played += s.Moves
+
+		// the last stage repeats
+		if i < len(tc.Stages) - 1 {
+			i++
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package clock_test
+
+import "../clock"
+
+import "testing"
+
+// time controls written by String are read back the same by Parse
+func TestParseRoundTrip(t *testing.T) {
+	for _, s := range []string{ "-", "300", "180+2", "300d5", "300b5.5", "300+2d5", "300+2b3", "40/5400+30:1800+30", "40/7200:3600d30" } {
+		tc, err := clock.Parse(s)
+
+		if err != nil {
+			t.Errorf("%s: %s", s, err)
+			continue
+		}
+
+		if got := tc.String(); got != s {
+			t.Errorf("%s is written as %s", s, got)
+		}
+	}
+
+	// the order of the suffixes doesn't matter
+	if tc, err := clock.Parse("300d5+2"); err != nil || tc.String() != "300+2d5" {
+		t.Errorf("300d5+2 is read as %s, %v", tc, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]error{
+		"?": clock.ErrUnknown,
+		"": clock.ErrUnknown,
+		"*60": clock.ErrSandclock,
+	}
+
+	for s, want := range cases {
+		if _, err := clock.Parse(s); err != want {
+			t.Errorf("%q: got %v, want %v", s, err, want)
+		}
+	}
+
+	for _, s := range []string{ "x", "300+", "300+2+3", "300d5b5", "0/300", "40/", "-5", "0", "40/0+30",
+		"inf", "+Inf", "NaN", "1e3", "1e400", "0x1p4", "300+inf", "300+-2", "300dNaN", "300b-1" } {
+		if _, err := clock.Parse(s); err == nil {
+			t.Errorf("%q parsed", s)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"../../analysis"
+	"../../pgn"
+	"../../search"
+	"../../uci"
+)
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+func main() {
+	t := analysis.DefaultThresholds
+
+	depth := flag.Int("depth", 8, "search depth per position")
+	nodes := flag.Int("nodes", 0, "node limit per position (0 for none)")
+	movetime := flag.Duration("movetime", 0, "engine time per position, e.g. 500ms")
+	engine := flag.String("engine", "", "path of an external UCI engine")
+	threads := flag.Int("threads", 1, "search threads")
+	hash := flag.Int("hash", search.DefaultHashMB, "hash table size in MB")
+
+	flag.IntVar(&t.Inaccuracy, "inaccuracy", t.Inaccuracy, "centipawns lost for ?!")
+	flag.IntVar(&t.Mistake, "mistake", t.Mistake, "centipawns lost for ?")
+	flag.IntVar(&t.Blunder, "blunder", t.Blunder, "centipawns lost for ??")
+	flag.Parse()
+
+	var ev analysis.Evaluator
+	var annotator string
+
+	if *engine != "" {
+		client, err := uci.Start(*engine)
+
+		if err != nil {
+			fail(err)
+		}
+
+		defer client.Close()
+
+		client.SetOption("Threads", fmt.Sprint(*threads))
+		client.SetOption("Hash", fmt.Sprint(*hash))
+
+		if err = client.IsReady(); err != nil {
+			fail(err)
+		}
+
+		ev = &analysis.Engine{
+			Client: client,
+			Limits: uci.Go{ Depth: *depth, Nodes: *nodes, MoveTime: *movetime },
+		}
+
+		// a time limit replaces the default depth
+		if *movetime > 0 && isSet("depth") == false {
+			ev.(*analysis.Engine).Limits.Depth = 0
+		}
+
+		annotator = client.Name
+	} else {
+		s := search.New()
+		s.Threads = *threads
+		s.Table = search.NewTable(*hash)
+
+		ev = &analysis.Local{
+			Searcher: s,
+			Limits: search.Limits{ Depth: *depth, Nodes: *nodes },
+		}
+
+		annotator = "gochess"
+	}
+
+	r := input()
+	games := make(chan *pgn.PGN)
+
+	var err error
+
+	go pgn.Read(r, games, &err)
+
+	for game := range games {
+		start := time.Now()
+
+		if err := analysis.Annotate(game, ev, t); err != nil {
+			fail(err)
+		}
+
+		game.Tags["Annotator"] = annotator
+
+		if err := pgn.Write(os.Stdout, game); err != nil {
+			fail(err)
+		}
+
+		fmt.Fprintf(os.Stderr, "analyzed %s - %s in %s\n", game.Tags["White"], game.Tags["Black"], time.Since(start))
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fail(err)
+	}
+}
+
+// input reads the files named, or stdin.
+func input() io.Reader {
+	if flag.NArg() == 0 {
+		return os.Stdin
+	}
+
+	readers := make([]io.Reader, 0, flag.NArg())
+
+	for _, name := range flag.Args() {
+		f, err := os.Open(name)
+
+		if err != nil {
+			fail(err)
+		}
+
+		readers = append(readers, f)
+	}
+
+	return io.MultiReader(readers...)
+}
+
+func isSet(name string) bool {
+	set := false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
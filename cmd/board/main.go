@@ -0,0 +1,221 @@
+package main
+
+import (
+	"../../chess"
+	"../../fen"
+	"../../pgn"
+	"../../render"
+)
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+var themes = map[string]*render.Theme{
+	"brown": render.BrownTheme,
+	"blue": render.BlueTheme,
+	"green": render.GreenTheme,
+}
+
+// repeatable flags
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *list) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+func main() {
+	var arrows, markers list
+
+	opts := render.DefaultText
+
+	flag.BoolVar(&opts.Unicode, "unicode", false, "figurines and box drawing")
+	flag.BoolVar(&opts.ANSI, "ansi", false, "colored squares")
+	flag.BoolVar(&opts.Flip, "flip", false, "black at the bottom")
+	flag.BoolVar(&opts.Coordinates, "coordinates", true, "show ranks and files")
+	compact := flag.Bool("compact", false, "a character per square")
+	svg := flag.Bool("svg", false, "write an SVG diagram")
+	png := flag.Bool("png", false, "write a PNG picture")
+	gif := flag.Bool("gif", false, "write a GIF animation of the whole game, with -pgn")
+	size := flag.Int("size", render.DefaultSVG.Size, "width of the SVG diagram or picture")
+	delay := flag.Duration("delay", time.Second, "time each move of the GIF animation is shown")
+	colors := flag.String("theme", "brown", "colors of the squares: brown, blue or green")
+	game := flag.String("pgn", "", "show the end of the first game in a PGN file instead of a FEN")
+	ply := flag.Int("ply", -1, "show the game after this many plies")
+	flag.Var(&arrows, "arrow", "draw an arrow, e.g. Re2e4 (repeatable)")
+	flag.Var(&markers, "mark", "mark a square, e.g. Gd5 (repeatable)")
+	flag.Parse()
+
+	if *compact {
+		opts.Style = render.Compact
+	}
+
+	if opts.Theme = themes[*colors]; opts.Theme == nil {
+		fail(fmt.Errorf("unknown theme %s", *colors))
+	}
+
+	if *gif {
+		if *game == "" {
+			fail(fmt.Errorf("-gif needs a game from -pgn"))
+		}
+
+		image := render.DefaultImage
+		image.Size, image.Theme, image.Flip, image.Coordinates = *size, opts.Theme, opts.Flip, opts.Coordinates
+
+		if err := render.GIF(os.Stdout, load(*game), image, *delay); err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	var g *chess.Game
+
+	switch {
+		case *game != "":
+			var last *pgn.Move
+
+			// the arrows and circles of the last move are drawn too
+			if g, last = replay(load(*game), *ply); last != nil {
+				opts.LastMove = last.Move
+				opts.Arrows, opts.Markers = render.Annotations(last.Comment)
+			}
+			break
+		case flag.NArg() > 0:
+			g = fen.Parse(strings.Join(flag.Args(), " "))
+			break
+		default:
+			g = chess.NewGame()
+			break
+	}
+
+	if g == nil {
+		fail(fmt.Errorf("invalid position"))
+	}
+
+	for _, s := range arrows {
+		a, ok := render.ParseArrow(s)
+
+		if ok == false {
+			fail(fmt.Errorf("bad arrow %s", s))
+		}
+
+		opts.Arrows = append(opts.Arrows, a)
+	}
+
+	for _, s := range markers {
+		m, ok := render.ParseMarker(s)
+
+		if ok == false {
+			fail(fmt.Errorf("bad square %s", s))
+		}
+
+		opts.Markers = append(opts.Markers, m)
+	}
+
+	if *png {
+		err := render.PNG(os.Stdout, g, render.ImageOptions{
+			Size: *size,
+			Theme: opts.Theme,
+			Flip: opts.Flip,
+			Coordinates: opts.Coordinates,
+			Check: opts.Check,
+			LastMove: opts.LastMove,
+			Arrows: opts.Arrows,
+			Markers: opts.Markers,
+		})
+
+		if err != nil {
+			fail(err)
+		}
+		return
+	}
+
+	if *svg {
+		fmt.Print(render.SVG(g, render.SVGOptions{
+			Size: *size,
+			Theme: opts.Theme,
+			Flip: opts.Flip,
+			Coordinates: opts.Coordinates,
+			Check: opts.Check,
+			LastMove: opts.LastMove,
+			Arrows: opts.Arrows,
+			Markers: opts.Markers,
+		}))
+		return
+	}
+
+	if err := render.Text(os.Stdout, g, opts); err != nil {
+		fail(err)
+	}
+}
+
+// load the first game of a file.
+func load(filename string) *pgn.PGN {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		fail(err)
+	}
+
+	defer f.Close()
+
+	ch := make(chan *pgn.PGN)
+
+	go pgn.Read(f, ch, &err)
+
+	var game *pgn.PGN
+
+	for x := range ch {
+		if game == nil {
+			game = x
+		}
+	}
+
+	if err != nil {
+		fail(err)
+	}
+
+	if game == nil {
+		fail(fmt.Errorf("no games in %s", filename))
+	}
+
+	return game
+}
+
+// replay a game up to a ply, or to the end.
+func replay(game *pgn.PGN, ply int) (*chess.Game, *pgn.Move) {
+	g := game.Setup()
+
+	if g == nil {
+		fail(render.ErrSetup)
+	}
+
+	var last *pgn.Move
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m == nil || ply == 0 {
+				continue
+			}
+
+			g.PerformMove(m.Move)
+			last, ply = m, ply - 1
+		}
+	}
+
+	return g, last
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"../../eco"
+	"../../pgn"
+)
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	flag.Parse()
+
+	// read games from the files given or stdin
+	var r io.Reader = os.Stdin
+
+	if flag.NArg() > 0 {
+		readers := make([]io.Reader, 0, flag.NArg())
+
+		for _, name := range flag.Args() {
+			f, err := os.Open(name)
+
+			if err != nil {
+				fail(err)
+			}
+
+			defer f.Close()
+			readers = append(readers, f)
+		}
+
+		r = io.MultiReader(readers...)
+	}
+
+	var err error
+
+	games := make(chan *pgn.PGN)
+
+	// tag each game with its opening and write it back out
+	go pgn.Read(r, games, &err)
+
+	for game := range games {
+		eco.Tag(game)
+
+		if err := pgn.Write(os.Stdout, game); err != nil {
+			fail(err)
+		}
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
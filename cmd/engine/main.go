@@ -0,0 +1,15 @@
+package main
+
+import "../../uci"
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	if err := uci.NewEngine(os.Stdout).Run(os.Stdin); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
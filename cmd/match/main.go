@@ -0,0 +1,169 @@
+package main
+
+import (
+	"../../match"
+	"../../pgn"
+	"../../stats"
+)
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// engines are given as key=value fields, e.g.
+// -engine "cmd=./stockfish name=SF option.Hash=64"
+type engines []match.Engine
+
+func (e *engines) String() string {
+	return fmt.Sprint(len(*e), " engines")
+}
+
+func (e *engines) Set(s string) error {
+	engine := match.Engine{ Options: make(map[string]string) }
+
+	for _, field := range strings.Fields(s) {
+		i := strings.Index(field, "=")
+
+		if i < 0 {
+			return fmt.Errorf("expected key=value, not %s", field)
+		}
+
+		key, value := field[:i], field[i + 1:]
+
+		switch {
+			case key == "cmd":
+				engine.Path = value
+				break
+			case key == "name":
+				engine.Name = value
+				break
+			case key == "arg":
+				engine.Args = append(engine.Args, value)
+				break
+			case strings.HasPrefix(key, "option."):
+				engine.Options[strings.TrimPrefix(key, "option.")] = value
+				break
+			default:
+				return fmt.Errorf("unknown engine setting %s", key)
+		}
+	}
+
+	if engine.Path == "" {
+		return fmt.Errorf("no cmd for the engine")
+	}
+
+	*e = append(*e, engine)
+	return nil
+}
+
+func main() {
+	var players engines
+	var adj match.Adjudication
+
+	flag.Var(&players, "engine", "engine as \"cmd=path [name=name] [arg=arg] [option.name=value]\", given twice")
+	tc := flag.String("tc", "", "time control in seconds as moves/time+inc, e.g. 40/60+0.6 or 10+0.1")
+	movetime := flag.Duration("movetime", 0, "time per move instead of a clock")
+	depth := flag.Int("depth", 0, "depth per move (0 for none)")
+	nodes := flag.Int("nodes", 0, "nodes per move (0 for none)")
+	margin := flag.Duration("margin", 50 * time.Millisecond, "time allowed past the clock")
+	games := flag.Int("games", 2, "number of games, two for each opening")
+	concurrency := flag.Int("concurrency", 1, "games played at a time")
+	openings := flag.String("openings", "", "EPD or PGN file of openings")
+	plies := flag.Int("plies", 0, "moves played from each PGN opening (0 for all)")
+	event := flag.String("event", "", "PGN Event tag")
+
+	flag.IntVar(&adj.WinScore, "win-score", 0, "centipawns to adjudicate a win")
+	flag.IntVar(&adj.WinMoves, "win-moves", 0, "moves in a row at -win-score to adjudicate a win (0 for never)")
+	flag.IntVar(&adj.DrawStart, "draw-start", 40, "first move to adjudicate a draw")
+	flag.IntVar(&adj.DrawScore, "draw-score", 10, "centipawns to adjudicate a draw")
+	flag.IntVar(&adj.DrawMoves, "draw-moves", 0, "moves in a row within -draw-score to adjudicate a draw (0 for never)")
+	flag.IntVar(&adj.MaxMoves, "max-moves", 0, "moves before the game is drawn (0 for no limit)")
+	tablebase := flag.String("tablebase", "", "tablebase server to adjudicate endgames, e.g. https://tablebase.lichess.ovh/standard")
+	flag.Parse()
+
+	if len(players) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: match -engine cmd=path -engine cmd=path [flags] > games.pgn")
+		os.Exit(2)
+	}
+
+	if *tablebase != "" {
+		adj.Tablebase = &match.Server{ URL: *tablebase }
+	}
+
+	m := &match.Match{
+		Adjudication: adj,
+		Games: *games,
+		Concurrency: *concurrency,
+		Event: *event,
+	}
+
+	copy(m.Engines[:], players)
+
+	if *tc != "" {
+		var err error
+
+		if m.TimeControl, err = match.ParseTimeControl(*tc); err != nil {
+			fail(err)
+		}
+	}
+
+	m.TimeControl.MoveTime = *movetime
+	m.TimeControl.Depth = *depth
+	m.TimeControl.Nodes = *nodes
+	m.TimeControl.Margin = *margin
+
+	if *tc == "" && *movetime == 0 && *depth == 0 && *nodes == 0 {
+		fail(fmt.Errorf("no time control: use -tc, -movetime, -depth or -nodes"))
+	}
+
+	if *openings != "" {
+		var err error
+
+		if m.Openings, err = match.LoadOpenings(*openings, *plies); err != nil {
+			fail(err)
+		}
+	}
+
+	var names [2]string
+
+	score, err := m.Run(func(game *match.Game) {
+		pgn.Write(os.Stdout, game.PGN)
+
+		// engines are named by their id once running
+		names[game.White] = game.PGN.Tags["White"]
+		names[1 - game.White] = game.PGN.Tags["Black"]
+
+		fmt.Fprintf(os.Stderr, "game %d: %s vs %s: %s {%s}\n",
+			game.Number,
+			game.PGN.Tags["White"],
+			game.PGN.Tags["Black"],
+			result(game.PGN),
+			game.Reason)
+	})
+
+	results := stats.Results{ Wins: score.Wins, Draws: score.Draws, Losses: score.Losses }
+
+	fmt.Fprintf(os.Stderr, "score of %s vs %s: %s\n", names[0], names[1], results)
+
+	if err != nil {
+		fail(err)
+	}
+}
+
+func result(game *pgn.PGN) string {
+	switch game.Result {
+		case pgn.WhiteWins: return "1-0"
+		case pgn.BlackWins: return "0-1"
+		case pgn.Draw:      return "1/2-1/2"
+	}
+	return "*"
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"../../pgn"
+	"../../puzzle"
+)
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	opts := puzzle.DefaultOptions
+
+	flag.IntVar(&opts.Depth, "depth", opts.Depth, "search depth per position")
+	flag.IntVar(&opts.Nodes, "nodes", opts.Nodes, "node limit per search (0 for none)")
+	flag.IntVar(&opts.Winning, "winning", opts.Winning, "centipawns that win decisively")
+	flag.IntVar(&opts.MaxMoves, "moves", opts.MaxMoves, "longest solution in moves")
+	flag.Parse()
+
+	// read games from the files given or stdin
+	var r io.Reader = os.Stdin
+
+	if flag.NArg() > 0 {
+		readers := make([]io.Reader, 0, flag.NArg())
+
+		for _, name := range flag.Args() {
+			f, err := os.Open(name)
+
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			defer f.Close()
+			readers = append(readers, f)
+		}
+
+		r = io.MultiReader(readers...)
+	}
+
+	var err error
+
+	games := make(chan *pgn.PGN)
+	finder := puzzle.NewFinder(opts)
+	w := csv.NewWriter(os.Stdout)
+
+	w.Write([]string{ "FEN", "Moves", "SAN", "Rating", "Themes", "Site" })
+
+	// stream the games and write puzzles as they are found
+	go pgn.Read(r, games, &err)
+
+	for game := range games {
+		for _, p := range finder.Find(game) {
+			w.Write(p.Record())
+		}
+
+		w.Flush()
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
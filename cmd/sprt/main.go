@@ -0,0 +1,91 @@
+package main
+
+import (
+	"../../pgn"
+	"../../stats"
+)
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	s := stats.DefaultSPRT
+
+	player := flag.String("player", "", "player to test (white of the first game if not given)")
+	flag.Float64Var(&s.Elo0, "elo0", s.Elo0, "elo difference of H0")
+	flag.Float64Var(&s.Elo1, "elo1", s.Elo1, "elo difference of H1")
+	flag.Float64Var(&s.Alpha, "alpha", s.Alpha, "chance of wrongly passing")
+	flag.Float64Var(&s.Beta, "beta", s.Beta, "chance of wrongly failing")
+	flag.Parse()
+
+	// read games from the files given or stdin
+	var r io.Reader = os.Stdin
+
+	if flag.NArg() > 0 {
+		readers := make([]io.Reader, 0, flag.NArg())
+
+		for _, name := range flag.Args() {
+			f, err := os.Open(name)
+
+			if err != nil {
+				fail(err)
+			}
+
+			defer f.Close()
+			readers = append(readers, f)
+		}
+
+		r = io.MultiReader(readers...)
+	}
+
+	var err error
+
+	ch := make(chan *pgn.PGN)
+	games := make([]*pgn.PGN, 0, 256)
+
+	go pgn.Read(r, ch, &err)
+
+	for game := range ch {
+		games = append(games, game)
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fail(err)
+	}
+
+	if len(games) == 0 {
+		fail(fmt.Errorf("no games"))
+	}
+
+	if *player == "" {
+		*player = games[0].Tags["White"]
+	}
+
+	results, pairs := stats.Collect(games, *player)
+
+	fmt.Printf("%s: %s\n", *player, results)
+
+	// pairs give the better estimate when the games have them
+	llr := results.LLR(s)
+
+	if pairs.Pairs() > 0 {
+		fmt.Printf("pentanomial: %s\n", pairs)
+		llr = pairs.LLR(s)
+	}
+
+	lower, upper := s.Bounds()
+
+	fmt.Printf("LLR %.2f (%.2f, %.2f) [%g, %g]: %s\n", llr, lower, upper, s.Elo0, s.Elo1, s.Decide(llr))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"../../analysis"
+	"../../pgn"
+)
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "write the report as JSON")
+	player := flag.String("player", "", "only report this player")
+	flag.Parse()
+
+	// read games from the files given or stdin
+	var r io.Reader = os.Stdin
+
+	if flag.NArg() > 0 {
+		readers := make([]io.Reader, 0, flag.NArg())
+
+		for _, name := range flag.Args() {
+			f, err := os.Open(name)
+
+			if err != nil {
+				fail(err)
+			}
+
+			defer f.Close()
+			readers = append(readers, f)
+		}
+
+		r = io.MultiReader(readers...)
+	}
+
+	var err error
+
+	games := make(chan *pgn.PGN)
+	report := analysis.NewReport()
+
+	go pgn.Read(r, games, &err)
+
+	for game := range games {
+		if err := report.Add(game); err != nil {
+			fail(err)
+		}
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fail(err)
+	}
+
+	if *player != "" {
+		p := report.Player(*player)
+		report = analysis.NewReport()
+		report.Players = append(report.Players, p)
+	}
+
+	if *asJSON {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteTable(os.Stdout)
+	}
+
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"../../epd"
+	"../../search"
+	"../../suite"
+	"../../uci"
+)
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+func main() {
+	depth := flag.Int("depth", 0, "search depth per position (0 for none)")
+	nodes := flag.Int("nodes", 0, "node limit per position (0 for none)")
+	movetime := flag.Duration("movetime", time.Second, "time per position (0 for none)")
+	engine := flag.String("engine", "", "path of an external UCI engine")
+	threads := flag.Int("threads", 1, "search threads")
+	hash := flag.Int("hash", search.DefaultHashMB, "hash table size in MB")
+	min := flag.Int("min", 0, "exit with status 1 if fewer positions are solved")
+	quiet := flag.Bool("quiet", false, "only print the summary")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: suite [flags] suite.epd ...")
+		os.Exit(2)
+	}
+
+	// a depth or node limit replaces the default time
+	if isSet("movetime") == false && (*depth > 0 || *nodes > 0) {
+		*movetime = 0
+	}
+
+	var s suite.Searcher
+
+	if *engine != "" {
+		client, err := uci.Start(*engine)
+
+		if err != nil {
+			fail(err)
+		}
+
+		defer client.Close()
+
+		client.SetOption("Threads", fmt.Sprint(*threads))
+		client.SetOption("Hash", fmt.Sprint(*hash))
+
+		s = &suite.Engine{
+			Client: client,
+			Limits: uci.Go{ Depth: *depth, Nodes: *nodes, MoveTime: *movetime },
+		}
+	} else {
+		searcher := search.New()
+		searcher.Threads = *threads
+		searcher.Table = search.NewTable(*hash)
+
+		s = &suite.Local{
+			Searcher: searcher,
+			Limits: search.Limits{ Depth: *depth, Nodes: *nodes },
+			MoveTime: *movetime,
+		}
+	}
+
+	report := func(r suite.Result) {
+		if *quiet == false {
+			fmt.Println(r)
+		}
+	}
+
+	var total suite.Summary
+
+	for _, name := range flag.Args() {
+		positions, err := epd.ParseFile(name)
+
+		if err != nil {
+			fail(fmt.Errorf("%s: %s", name, err))
+		}
+
+		sum, err := suite.Run(positions, s, report)
+
+		if err != nil {
+			fail(err)
+		}
+
+		fmt.Printf("%s: %s\n", name, sum)
+
+		total.Positions += sum.Positions
+		total.Solved += sum.Solved
+		total.Time += sum.Time
+		total.SolveTime += sum.SolveTime
+		total.Nodes += sum.Nodes
+	}
+
+	if flag.NArg() > 1 {
+		fmt.Printf("total: %s\n", total)
+	}
+
+	if total.Solved < *min {
+		os.Exit(1)
+	}
+}
+
+func isSet(name string) bool {
+	set := false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
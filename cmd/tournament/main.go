@@ -0,0 +1,160 @@
+package main
+
+import (
+	"../../pgn"
+	"../../tournament"
+)
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	system := flag.String("system", "swiss", "pairing system, swiss or roundrobin")
+	cycles := flag.Int("cycles", 1, "cycles of a round robin")
+	rounds := flag.Int("rounds", 0, "rounds of a Swiss (0 for no limit)")
+	bye := flag.Float64("bye", 1, "points for a Swiss bye")
+	players := flag.String("players", "", "file of players, a name and rating on each line")
+	pair := flag.Bool("pair", false, "pair the next round")
+	flag.Parse()
+
+	t := tournament.New("", tournament.Swiss)
+	t.Cycles = *cycles
+	t.Rounds = *rounds
+	t.Bye = *bye
+
+	switch *system {
+		case "swiss":
+			break
+		case "roundrobin":
+			t.System = tournament.RoundRobin
+			break
+		default:
+			fail(fmt.Errorf("unknown system %s", *system))
+	}
+
+	if *players != "" {
+		if err := enter(t, *players); err != nil {
+			fail(err)
+		}
+	}
+
+	// results come from the files given, or stdin unless only pairing
+	// the first round
+	var r io.Reader = os.Stdin
+
+	switch {
+		case flag.NArg() > 0:
+			readers := make([]io.Reader, 0, flag.NArg())
+
+			for _, name := range flag.Args() {
+				f, err := os.Open(name)
+
+				if err != nil {
+					fail(err)
+				}
+
+				defer f.Close()
+				readers = append(readers, f)
+			}
+
+			r = io.MultiReader(readers...)
+			break
+		case *players != "":
+			r = strings.NewReader("")
+			break
+	}
+
+	var err error
+
+	ch := make(chan *pgn.PGN)
+	games := make([]*pgn.PGN, 0, 64)
+
+	go pgn.Read(r, ch, &err)
+
+	for game := range ch {
+		games = append(games, game)
+	}
+
+	// the games that couldn't be parsed were left out
+	if _, ok := err.(pgn.Skipped); ok {
+		fmt.Fprintln(os.Stderr, err)
+	} else if err != nil {
+		fail(err)
+	}
+
+	if err = t.Import(games); err != nil {
+		fail(err)
+	}
+
+	if len(games) > 0 {
+		t.Name = games[0].Tags["Event"]
+	}
+
+	if t.Played() > 0 {
+		t.WriteCrosstable(os.Stdout)
+	}
+
+	if *pair {
+		round, err := t.PairRound()
+
+		if err != nil {
+			fail(err)
+		}
+
+		fmt.Printf("\nround %d\n", t.Played())
+
+		for _, g := range round {
+			if g.Black == nil {
+				fmt.Printf("%3d. %s bye\n", g.Board, g.White.Name)
+			} else {
+				fmt.Printf("%3d. %s - %s\n", g.Board, g.White.Name, g.Black.Name)
+			}
+		}
+	}
+}
+
+// enter reads players from a file, each line a name and optionally a
+// rating after it.
+func enter(t *tournament.Tournament, filename string) error {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		rating, err := strconv.Atoi(fields[len(fields) - 1])
+
+		if err == nil && len(fields) > 1 {
+			fields = fields[:len(fields) - 1]
+		} else {
+			rating = 0
+		}
+
+		t.Add(strings.Join(fields, " "), rating)
+	}
+
+	return scanner.Err()
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,159 @@
+package eco
+
+import (
+	"../chess"
+	"../pgn"
+)
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// An Opening is an entry of the ECO table.
+type Opening struct {
+	ECO string                // code, A00 to E99
+	Name string               // opening, e.g. "Sicilian Defense"
+	Variation string          // variation within it, if any
+	Moves string              // moves in SAN reaching the position
+	Ply int                   // number of half moves
+}
+
+var reEntry = regexp.MustCompile("^([A-E]\\d\\d) \"([^\"]+)\" (.+)$")
+var reMoveNumber = regexp.MustCompile("^\\d+\\.+")
+
+var openings []*Opening
+var positions map[uint64]*Opening
+
+// the table is only parsed when first used
+var load sync.Once
+
+// Openings returns every entry of the table, in order.
+func Openings() []*Opening {
+	load.Do(parseTable)
+
+	return openings
+}
+
+func parseTable() {
+	positions = make(map[uint64]*Opening)
+
+	for i, line := range strings.Split(strings.TrimSpace(table), "\n") {
+		m := reEntry.FindStringSubmatch(line)
+
+		if m == nil {
+			panic(fmt.Sprintf("eco: bad entry on line %d", i + 1))
+		}
+
+		o := &Opening{ ECO: m[1], Name: m[2], Moves: m[3] }
+
+		// names are "Opening: Variation"
+		if i := strings.Index(o.Name, ": "); i >= 0 {
+			o.Name, o.Variation = o.Name[:i], o.Name[i + 2:]
+		}
+
+		g := chess.NewGame()
+
+		for _, s := range strings.Fields(o.Moves) {
+			move := g.ParseMove(reMoveNumber.ReplaceAllString(s, ""))
+
+			if move == nil {
+				panic(fmt.Sprintf("eco: illegal move %s in %s", s, o.ECO))
+			}
+
+			g.PerformMove(move)
+			o.Ply++
+		}
+
+		openings = append(openings, o)
+
+		// the first entry reaching a position names it
+		if _, ok := positions[g.Hash()]; ok == false {
+			positions[g.Hash()] = o
+		}
+	}
+}
+
+// FullName is the opening and variation, as named in the table.
+func (o *Opening) FullName() string {
+	if o.Variation == "" {
+		return o.Name
+	}
+	return o.Name + ": " + o.Variation
+}
+
+// Lookup finds the opening of a position, or nil if it isn't in the
+// table. Positions are matched regardless of the moves that reached
+// them.
+func Lookup(g *chess.Game) *Opening {
+	load.Do(parseTable)
+
+	// only standard chess has openings
+	if g.IsStandard() == false {
+		return nil
+	}
+
+	return positions[g.Hash()]
+}
+
+// Classify plays moves from a starting position, and returns the
+// opening of the last position found in the table, so transpositions
+// into a known line are caught. The game isn't changed.
+func Classify(g *chess.Game, moves []*chess.Move) *Opening {
+	pos := *g
+	opening := Lookup(&pos)
+
+	for _, move := range moves {
+		pos.PerformMove(move)
+
+		if o := Lookup(&pos); o != nil {
+			opening = o
+		}
+	}
+
+	return opening
+}
+
+// ClassifyPGN returns the opening of a game, or nil if none is known.
+func ClassifyPGN(game *pgn.PGN) *Opening {
+	g := game.Setup()
+
+	if g == nil {
+		return nil
+	}
+
+	moves := make([]*chess.Move, 0, len(game.Moves) * 2)
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m != nil {
+				moves = append(moves, m.Move)
+			}
+		}
+	}
+
+	return Classify(g, moves)
+}
+
+// Tag classifies a game and sets its ECO, Opening and Variation tags.
+// The tags are left alone if the opening isn't known.
+func Tag(game *pgn.PGN) *Opening {
+	o := ClassifyPGN(game)
+
+	if o == nil {
+		return nil
+	}
+
+	game.Tags["ECO"] = o.ECO
+	game.Tags["Opening"] = o.Name
+
+	if o.Variation != "" {
+		game.Tags["Variation"] = o.Variation
+	} else {
+		delete(game.Tags, "Variation")
+	}
+
+	return o
+}
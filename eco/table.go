@@ -0,0 +1,683 @@
+package eco
+
+// the ECO codes A00 to E99, each with its name and a line of moves
+// reaching it. Names are "Opening: Variation". Codes may appear more
+// than once for named lines within them.
+const table = `
+A00 "Polish Opening" 1.b4
+A00 "Hungarian Opening" 1.g3
+A00 "Anderssen's Opening" 1.a3
+A00 "Ware Opening" 1.a4
+A00 "Durkin Opening" 1.Na3
+A00 "Van Geet Opening" 1.Nc3
+A00 "Mieses Opening" 1.d3
+A00 "Van't Kruijs Opening" 1.e3
+A00 "Barnes Opening" 1.f3
+A00 "Grob Opening" 1.g4
+A00 "Clemenz Opening" 1.h3
+A00 "Kadas Opening" 1.h4
+A00 "Amar Opening" 1.Nh3
+A00 "Saragossa Opening" 1.c3
+A01 "Nimzo-Larsen Attack" 1.b3
+A02 "Bird Opening" 1.f4
+A02 "Bird Opening: From's Gambit" 1.f4 e5
+A03 "Bird Opening: Dutch Variation" 1.f4 d5
+A04 "Zukertort Opening" 1.Nf3
+A04 "Zukertort Opening: Sicilian Invitation" 1.Nf3 c5
+A05 "Zukertort Opening: Anglo-Indian" 1.Nf3 Nf6
+A06 "Zukertort Opening: Queen's Pawn" 1.Nf3 d5
+A07 "King's Indian Attack" 1.Nf3 d5 2.g3
+A08 "King's Indian Attack: Sicilian Variation" 1.Nf3 d5 2.g3 c5 3.Bg2
+A09 "Réti Opening" 1.Nf3 d5 2.c4
+A09 "Réti Opening: Advance Variation" 1.Nf3 d5 2.c4 d4
+A09 "Réti Opening: Réti Accepted" 1.Nf3 d5 2.c4 dxc4
+A10 "English Opening" 1.c4
+A10 "English Opening: Great Snake Variation" 1.c4 g6
+A10 "English Opening: Anglo-Dutch Defense" 1.c4 f5
+A11 "English Opening: Caro-Kann Defensive System" 1.c4 c6
+A12 "English Opening: Caro-Kann Defensive System, Bogoljubov Variation" 1.c4 c6 2.Nf3 d5 3.b3
+A13 "English Opening: Agincourt Defense" 1.c4 e6
+A14 "English Opening: Agincourt Defense, Neo-Catalan Declined" 1.c4 e6 2.Nf3 d5 3.g3 Nf6 4.Bg2 Be7 5.O-O
+A15 "English Opening: Anglo-Indian Defense" 1.c4 Nf6
+A16 "English Opening: Anglo-Indian Defense, Queen's Knight Variation" 1.c4 Nf6 2.Nc3
+A17 "English Opening: Anglo-Indian Defense, Hedgehog System" 1.c4 Nf6 2.Nc3 e6
+A18 "English Opening: Mikenas-Carls Variation" 1.c4 Nf6 2.Nc3 e6 3.e4
+A19 "English Opening: Mikenas-Carls, Sicilian Variation" 1.c4 Nf6 2.Nc3 e6 3.e4 c5
+A20 "English Opening: King's English Variation" 1.c4 e5
+A21 "English Opening: King's English Variation, Reversed Sicilian" 1.c4 e5 2.Nc3
+A22 "English Opening: King's English Variation, Two Knights Variation" 1.c4 e5 2.Nc3 Nf6
+A23 "English Opening: King's English Variation, Bremen System, Keres Variation" 1.c4 e5 2.Nc3 Nf6 3.g3 c6
+A24 "English Opening: King's English Variation, Bremen System with ...g6" 1.c4 e5 2.Nc3 Nf6 3.g3 g6
+A25 "English Opening: King's English Variation, Sicilian Reversed" 1.c4 e5 2.Nc3 Nc6
+A26 "English Opening: King's English Variation, Botvinnik System" 1.c4 e5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.d3 d6
+A27 "English Opening: King's English Variation, Three Knights System" 1.c4 e5 2.Nc3 Nc6 3.Nf3
+A28 "English Opening: King's English Variation, Four Knights Variation" 1.c4 e5 2.Nc3 Nc6 3.Nf3 Nf6
+A29 "English Opening: King's English Variation, Four Knights, Fianchetto Line" 1.c4 e5 2.Nc3 Nc6 3.Nf3 Nf6 4.g3
+A30 "English Opening: Symmetrical Variation" 1.c4 c5
+A31 "English Opening: Symmetrical Variation, Anti-Benoni Variation" 1.c4 c5 2.Nf3 Nf6 3.d4
+A32 "English Opening: Symmetrical Variation, Anti-Benoni Variation, Spielmann Defense" 1.c4 c5 2.Nf3 Nf6 3.d4 cxd4 4.Nxd4 e6
+A33 "English Opening: Symmetrical Variation, Anti-Benoni Variation, Geller Variation" 1.c4 c5 2.Nf3 Nf6 3.d4 cxd4 4.Nxd4 e6 5.Nc3 Nc6
+A34 "English Opening: Symmetrical Variation, Normal Variation" 1.c4 c5 2.Nc3
+A35 "English Opening: Symmetrical Variation, Four Knights Variation" 1.c4 c5 2.Nc3 Nc6
+A36 "English Opening: Symmetrical Variation, Fianchetto Variation" 1.c4 c5 2.Nc3 Nc6 3.g3
+A36 "English Opening: Symmetrical Variation, Botvinnik Variation" 1.c4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.e4
+A37 "English Opening: Symmetrical Variation, Two Knights Line" 1.c4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.Nf3
+A38 "English Opening: Symmetrical Variation, Full Symmetry Line" 1.c4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.Nf3 Nf6
+A39 "English Opening: Symmetrical Variation, Mecking Variation" 1.c4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.Nf3 Nf6 6.O-O O-O 7.d4
+A40 "Queen's Pawn Game" 1.d4
+A40 "Englund Gambit" 1.d4 e5
+A40 "Horwitz Defense" 1.d4 e6
+A40 "Modern Defense" 1.d4 g6
+A40 "Polish Defense" 1.d4 b5
+A40 "English Defense" 1.d4 e6 2.c4 b6
+A41 "Queen's Pawn Game: Wade Defense" 1.d4 d6
+A41 "Old Indian Defense" 1.d4 d6 2.c4
+A42 "Modern Defense: Averbakh System" 1.d4 d6 2.c4 g6 3.Nc3 Bg7 4.e4
+A43 "Benoni Defense: Old Benoni" 1.d4 c5
+A44 "Benoni Defense: Old Benoni, Czech Variation" 1.d4 c5 2.d5 e5
+A45 "Indian Defense" 1.d4 Nf6
+A45 "Trompowsky Attack" 1.d4 Nf6 2.Bg5
+A46 "Indian Defense: Knights Variation" 1.d4 Nf6 2.Nf3
+A46 "Torre Attack" 1.d4 Nf6 2.Nf3 e6 3.Bg5
+A46 "London System" 1.d4 Nf6 2.Nf3 e6 3.Bf4
+A47 "Queen's Indian Defense: Marienbad System" 1.d4 Nf6 2.Nf3 b6
+A48 "East Indian Defense" 1.d4 Nf6 2.Nf3 g6
+A48 "London System" 1.d4 Nf6 2.Nf3 g6 3.Bf4
+A49 "East Indian Defense: Przepiorka Variation" 1.d4 Nf6 2.Nf3 g6 3.g3
+A50 "Indian Defense: Normal Variation" 1.d4 Nf6 2.c4
+A50 "Indian Defense: Mexican Defense" 1.d4 Nf6 2.c4 Nc6
+A51 "Indian Defense: Budapest Defense" 1.d4 Nf6 2.c4 e5
+A51 "Indian Defense: Budapest Defense, Fajarowicz Variation" 1.d4 Nf6 2.c4 e5 3.dxe5 Ne4
+A52 "Indian Defense: Budapest Defense, Rubinstein Variation" 1.d4 Nf6 2.c4 e5 3.dxe5 Ng4
+A53 "Old Indian Defense" 1.d4 Nf6 2.c4 d6
+A54 "Old Indian Defense: Ukrainian Variation" 1.d4 Nf6 2.c4 d6 3.Nc3 e5
+A55 "Old Indian Defense: Normal Variation" 1.d4 Nf6 2.c4 d6 3.Nc3 e5 4.Nf3 Nbd7 5.e4
+A56 "Benoni Defense" 1.d4 Nf6 2.c4 c5
+A56 "Benoni Defense: Czech Benoni" 1.d4 Nf6 2.c4 c5 3.d5 e5
+A57 "Benko Gambit" 1.d4 Nf6 2.c4 c5 3.d5 b5
+A58 "Benko Gambit Accepted" 1.d4 Nf6 2.c4 c5 3.d5 b5 4.cxb5 a6 5.bxa6
+A59 "Benko Gambit Accepted: King Walk Variation" 1.d4 Nf6 2.c4 c5 3.d5 b5 4.cxb5 a6 5.bxa6 Bxa6 6.Nc3 d6 7.e4
+A60 "Benoni Defense: Modern Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6
+A61 "Benoni Defense: Modern Variation, Normal Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.Nf3 g6
+A62 "Benoni Defense: Fianchetto Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.Nf3 g6 7.g3 Bg7 8.Bg2 O-O
+A63 "Benoni Defense: Fianchetto Variation, Hastings Defense" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.Nf3 g6 7.g3 Bg7 8.Bg2 O-O 9.O-O Nbd7
+A64 "Benoni Defense: Fianchetto Variation, Hastings Defense, Main Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.Nf3 g6 7.g3 Bg7 8.Bg2 O-O 9.O-O Nbd7 10.Nd2 a6 11.a4 Re8
+A65 "Benoni Defense: King's Pawn Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4
+A66 "Benoni Defense: Pawn Storm Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.f4
+A67 "Benoni Defense: Taimanov Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.f4 Bg7 8.Bb5+
+A68 "Benoni Defense: Four Pawns Attack" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.f4 Bg7 8.Nf3 O-O
+A69 "Benoni Defense: Four Pawns Attack, Main Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.f4 Bg7 8.Nf3 O-O 9.Be2 Re8
+A70 "Benoni Defense: Classical Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3
+A71 "Benoni Defense: Classical Variation, Averbakh-Grivas Attack" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Bg5
+A72 "Benoni Defense: Classical Variation, Traditional Variation" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O
+A73 "Benoni Defense: Classical Variation, Main Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O
+A74 "Benoni Defense: Classical Variation, Full Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O a6 10.a4
+A75 "Benoni Defense: Classical Variation, Argentine Counterattack" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O a6 10.a4 Bg4
+A76 "Benoni Defense: Classical Variation, Czerniak Defense" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O Re8
+A77 "Benoni Defense: Classical Variation, Czerniak Defense, Tal Line" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O Re8 10.Nd2
+A78 "Benoni Defense: Classical Variation, Czerniak Defense, Tal Line, ...Na6" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O Re8 10.Nd2 Na6
+A79 "Benoni Defense: Classical Variation, Czerniak Defense, 11.f3" 1.d4 Nf6 2.c4 c5 3.d5 e6 4.Nc3 exd5 5.cxd5 d6 6.e4 g6 7.Nf3 Bg7 8.Be2 O-O 9.O-O Re8 10.Nd2 Na6 11.f3
+A80 "Dutch Defense" 1.d4 f5
+A81 "Dutch Defense: Fianchetto Attack" 1.d4 f5 2.g3
+A82 "Dutch Defense: Staunton Gambit" 1.d4 f5 2.e4
+A83 "Dutch Defense: Staunton Gambit, Lasker Variation" 1.d4 f5 2.e4 fxe4 3.Nc3 Nf6 4.Bg5
+A84 "Dutch Defense: Normal Variation" 1.d4 f5 2.c4
+A85 "Dutch Defense: Queen's Knight Variation" 1.d4 f5 2.c4 Nf6 3.Nc3
+A86 "Dutch Defense: Fianchetto Variation" 1.d4 f5 2.c4 Nf6 3.g3
+A87 "Dutch Defense: Leningrad Variation" 1.d4 f5 2.c4 Nf6 3.g3 g6 4.Bg2 Bg7 5.Nf3
+A88 "Dutch Defense: Leningrad Variation, Warsaw Variation" 1.d4 f5 2.c4 Nf6 3.g3 g6 4.Bg2 Bg7 5.Nf3 O-O 6.O-O d6 7.Nc3 c6
+A89 "Dutch Defense: Leningrad Variation, Matulovic Variation" 1.d4 f5 2.c4 Nf6 3.g3 g6 4.Bg2 Bg7 5.Nf3 O-O 6.O-O d6 7.Nc3 Nc6
+A90 "Dutch Defense: Classical Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2
+A91 "Dutch Defense: Classical Variation, Blackburne Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7
+A92 "Dutch Defense: Classical Variation, Main Line" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O
+A93 "Dutch Defense: Stonewall Variation, Botvinnik Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d5 7.b3
+A94 "Dutch Defense: Stonewall Variation, Modern Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d5 7.b3 c6 8.Ba3
+A95 "Dutch Defense: Stonewall Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d5 7.Nc3 c6
+A96 "Dutch Defense: Classical Variation, Ilyin-Zhenevsky Line" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d6
+A97 "Dutch Defense: Ilyin-Zhenevsky Variation" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d6 7.Nc3 Qe8
+A98 "Dutch Defense: Ilyin-Zhenevsky Variation, Alatortsev-Lisitsyn Line" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d6 7.Nc3 Qe8 8.Qc2
+A99 "Dutch Defense: Ilyin-Zhenevsky Variation, Modern Main Line" 1.d4 f5 2.c4 Nf6 3.g3 e6 4.Bg2 Be7 5.Nf3 O-O 6.O-O d6 7.Nc3 Qe8 8.b3
+B00 "King's Pawn Game" 1.e4
+B00 "Nimzowitsch Defense" 1.e4 Nc6
+B00 "Owen Defense" 1.e4 b6
+B00 "St. George Defense" 1.e4 a6
+B00 "Hippopotamus Defense" 1.e4 h6
+B01 "Scandinavian Defense" 1.e4 d5
+B01 "Scandinavian Defense: Main Line" 1.e4 d5 2.exd5 Qxd5 3.Nc3 Qa5
+B01 "Scandinavian Defense: Mieses-Kotroc Variation" 1.e4 d5 2.exd5 Qxd5
+B01 "Scandinavian Defense: Modern Variation" 1.e4 d5 2.exd5 Nf6
+B02 "Alekhine Defense" 1.e4 Nf6
+B03 "Alekhine Defense" 1.e4 Nf6 2.e5 Nd5 3.d4
+B03 "Alekhine Defense: Four Pawns Attack" 1.e4 Nf6 2.e5 Nd5 3.d4 d6 4.c4 Nb6 5.f4
+B03 "Alekhine Defense: Exchange Variation" 1.e4 Nf6 2.e5 Nd5 3.d4 d6 4.c4 Nb6 5.exd6
+B04 "Alekhine Defense: Modern Variation" 1.e4 Nf6 2.e5 Nd5 3.d4 d6 4.Nf3
+B05 "Alekhine Defense: Modern Variation, Main Line" 1.e4 Nf6 2.e5 Nd5 3.d4 d6 4.Nf3 Bg4
+B06 "Modern Defense" 1.e4 g6
+B06 "Modern Defense: Standard Line" 1.e4 g6 2.d4 Bg7 3.Nc3 d6
+B06 "Pterodactyl Defense" 1.e4 g6 2.d4 Bg7 3.Nc3 c5
+B07 "Pirc Defense" 1.e4 d6 2.d4 Nf6
+B07 "Pirc Defense: Main Line" 1.e4 d6 2.d4 Nf6 3.Nc3 g6
+B07 "Pirc Defense: 150 Attack" 1.e4 d6 2.d4 Nf6 3.Nc3 g6 4.Be3 c6 5.Qd2
+B08 "Pirc Defense: Classical Variation" 1.e4 d6 2.d4 Nf6 3.Nc3 g6 4.Nf3
+B09 "Pirc Defense: Austrian Attack" 1.e4 d6 2.d4 Nf6 3.Nc3 g6 4.f4
+B10 "Caro-Kann Defense" 1.e4 c6
+B10 "Caro-Kann Defense: Two Knights Attack" 1.e4 c6 2.Nc3 d5 3.Nf3
+B10 "Caro-Kann Defense: Accelerated Panov Attack" 1.e4 c6 2.c4
+B11 "Caro-Kann Defense: Two Knights Attack, Mindeno Variation" 1.e4 c6 2.Nc3 d5 3.Nf3 Bg4
+B12 "Caro-Kann Defense" 1.e4 c6 2.d4
+B12 "Caro-Kann Defense: Advance Variation" 1.e4 c6 2.d4 d5 3.e5
+B12 "Caro-Kann Defense: Advance Variation, Short Variation" 1.e4 c6 2.d4 d5 3.e5 Bf5 4.Nf3 e6 5.Be2
+B12 "Caro-Kann Defense: Maróczy Variation" 1.e4 c6 2.d4 d5 3.f3
+B13 "Caro-Kann Defense: Exchange Variation" 1.e4 c6 2.d4 d5 3.exd5
+B13 "Caro-Kann Defense: Panov Attack" 1.e4 c6 2.d4 d5 3.exd5 cxd5 4.c4
+B14 "Caro-Kann Defense: Panov Attack, Main Line" 1.e4 c6 2.d4 d5 3.exd5 cxd5 4.c4 Nf6 5.Nc3 e6
+B15 "Caro-Kann Defense" 1.e4 c6 2.d4 d5 3.Nc3
+B15 "Caro-Kann Defense: Main Line" 1.e4 c6 2.d4 d5 3.Nc3 dxe4 4.Nxe4
+B16 "Caro-Kann Defense: Bronstein-Larsen Variation" 1.e4 c6 2.d4 d5 3.Nc3 dxe4 4.Nxe4 Nf6 5.Nxf6+ gxf6
+B17 "Caro-Kann Defense: Karpov Variation" 1.e4 c6 2.d4 d5 3.Nc3 dxe4 4.Nxe4 Nd7
+B18 "Caro-Kann Defense: Classical Variation" 1.e4 c6 2.d4 d5 3.Nc3 dxe4 4.Nxe4 Bf5
+B19 "Caro-Kann Defense: Classical Variation, Spassky Variation" 1.e4 c6 2.d4 d5 3.Nc3 dxe4 4.Nxe4 Bf5 5.Ng3 Bg6 6.h4 h6 7.Nf3 Nd7
+B20 "Sicilian Defense" 1.e4 c5
+B20 "Sicilian Defense: Bowdler Attack" 1.e4 c5 2.Bc4
+B20 "Sicilian Defense: Wing Gambit" 1.e4 c5 2.b4
+B21 "Sicilian Defense: Grand Prix Attack" 1.e4 c5 2.f4
+B21 "Sicilian Defense: Smith-Morra Gambit" 1.e4 c5 2.d4 cxd4 3.c3
+B21 "Sicilian Defense: Smith-Morra Gambit Accepted" 1.e4 c5 2.d4 cxd4 3.c3 dxc3 4.Nxc3
+B22 "Sicilian Defense: Alapin Variation" 1.e4 c5 2.c3
+B23 "Sicilian Defense: Closed" 1.e4 c5 2.Nc3
+B23 "Sicilian Defense: Grand Prix Attack" 1.e4 c5 2.Nc3 Nc6 3.f4
+B24 "Sicilian Defense: Closed" 1.e4 c5 2.Nc3 Nc6 3.g3
+B25 "Sicilian Defense: Closed, Traditional" 1.e4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.d3 d6
+B26 "Sicilian Defense: Closed, 6.Be3" 1.e4 c5 2.Nc3 Nc6 3.g3 g6 4.Bg2 Bg7 5.d3 d6 6.Be3
+B27 "Sicilian Defense" 1.e4 c5 2.Nf3
+B27 "Sicilian Defense: Hyperaccelerated Dragon" 1.e4 c5 2.Nf3 g6
+B28 "Sicilian Defense: O'Kelly Variation" 1.e4 c5 2.Nf3 a6
+B29 "Sicilian Defense: Nimzowitsch Variation" 1.e4 c5 2.Nf3 Nf6
+B30 "Sicilian Defense: Old Sicilian" 1.e4 c5 2.Nf3 Nc6
+B30 "Sicilian Defense: Nyezhmetdinov-Rossolimo Attack" 1.e4 c5 2.Nf3 Nc6 3.Bb5
+B31 "Sicilian Defense: Nyezhmetdinov-Rossolimo Attack, Fianchetto Variation" 1.e4 c5 2.Nf3 Nc6 3.Bb5 g6
+B32 "Sicilian Defense: Open" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4
+B32 "Sicilian Defense: Löwenthal Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 e5
+B33 "Sicilian Defense: Four Knights Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 Nf6
+B33 "Sicilian Defense: Lasker-Pelikan Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e5
+B33 "Sicilian Defense: Sveshnikov Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e5 6.Ndb5 d6
+B34 "Sicilian Defense: Accelerated Dragon, Exchange Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.Nxc6
+B34 "Sicilian Defense: Accelerated Dragon" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6
+B35 "Sicilian Defense: Accelerated Dragon, Modern Bc4 Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.Nc3 Bg7 6.Be3 Nf6 7.Bc4
+B36 "Sicilian Defense: Accelerated Dragon, Maróczy Bind" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.c4
+B37 "Sicilian Defense: Accelerated Dragon, Maróczy Bind, 5...Bg7" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.c4 Bg7
+B38 "Sicilian Defense: Accelerated Dragon, Maróczy Bind, 6.Be3" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.c4 Bg7 6.Be3
+B39 "Sicilian Defense: Accelerated Dragon, Maróczy Bind, Breyer Variation" 1.e4 c5 2.Nf3 Nc6 3.d4 cxd4 4.Nxd4 g6 5.c4 Bg7 6.Be3 Nf6 7.Nc3 Ng4
+B40 "Sicilian Defense: French Variation" 1.e4 c5 2.Nf3 e6
+B40 "Sicilian Defense: Four Knights Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6
+B41 "Sicilian Defense: Kan Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 a6
+B41 "Sicilian Defense: Kan Variation, Maróczy Bind" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 a6 5.c4
+B42 "Sicilian Defense: Kan Variation, Modern Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 a6 5.Bd3
+B43 "Sicilian Defense: Kan Variation, Wing Attack" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 a6 5.Nc3
+B44 "Sicilian Defense: Taimanov Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6
+B45 "Sicilian Defense: Taimanov Variation, Normal Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6 5.Nc3
+B46 "Sicilian Defense: Taimanov Variation, 5...a6" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6 5.Nc3 a6
+B47 "Sicilian Defense: Taimanov Variation, Bastrikov Variation" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6 5.Nc3 Qc7
+B48 "Sicilian Defense: Taimanov Variation, English Attack" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6 5.Nc3 Qc7 6.Be3
+B49 "Sicilian Defense: Taimanov Variation, Bastrikov Variation, 7.Be2" 1.e4 c5 2.Nf3 e6 3.d4 cxd4 4.Nxd4 Nc6 5.Nc3 Qc7 6.Be3 a6 7.Be2
+B50 "Sicilian Defense: Modern Variations" 1.e4 c5 2.Nf3 d6
+B51 "Sicilian Defense: Moscow Variation" 1.e4 c5 2.Nf3 d6 3.Bb5+
+B52 "Sicilian Defense: Moscow Variation, 3...Bd7" 1.e4 c5 2.Nf3 d6 3.Bb5+ Bd7
+B53 "Sicilian Defense: Chekhover Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Qxd4
+B54 "Sicilian Defense: Modern Variations, Main Line" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4
+B55 "Sicilian Defense: Prins Variation, Venice Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.f3 e5 6.Bb5+
+B56 "Sicilian Defense: Open" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3
+B56 "Sicilian Defense: Classical Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6
+B57 "Sicilian Defense: Sozin Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bc4
+B58 "Sicilian Defense: Classical Variation, Fianchetto Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Be2
+B59 "Sicilian Defense: Boleslavsky Variation, 7.Nb3" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Be2 e5 7.Nb3
+B60 "Sicilian Defense: Richter-Rauzer Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5
+B61 "Sicilian Defense: Richter-Rauzer Variation, Larsen Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 Bd7 7.Qd2
+B62 "Sicilian Defense: Richter-Rauzer Variation, 6...e6" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6
+B63 "Sicilian Defense: Richter-Rauzer Variation, Classical Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 Be7
+B64 "Sicilian Defense: Richter-Rauzer Variation, Classical Variation, 9.f4" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 Be7 8.O-O-O O-O 9.f4
+B65 "Sicilian Defense: Richter-Rauzer Variation, Classical Variation, 9...Nxd4" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 Be7 8.O-O-O O-O 9.f4 Nxd4 10.Qxd4
+B66 "Sicilian Defense: Richter-Rauzer Variation, Neo-Modern Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 a6
+B67 "Sicilian Defense: Richter-Rauzer Variation, Neo-Modern Variation, 8...Bd7" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 a6 8.O-O-O Bd7
+B68 "Sicilian Defense: Richter-Rauzer Variation, Neo-Modern Variation, 9...Be7" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 a6 8.O-O-O Bd7 9.f4 Be7
+B69 "Sicilian Defense: Richter-Rauzer Variation, Neo-Modern Variation, 11.Bxf6" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bg5 e6 7.Qd2 a6 8.O-O-O Bd7 9.f4 Be7 10.Nf3 b5 11.Bxf6
+B70 "Sicilian Defense: Dragon Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6
+B71 "Sicilian Defense: Dragon Variation, Levenfish Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.f4
+B72 "Sicilian Defense: Dragon Variation, 6.Be3" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3
+B73 "Sicilian Defense: Dragon Variation, Classical Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.Be2 Nc6 8.O-O
+B74 "Sicilian Defense: Dragon Variation, Classical Variation, Normal Line" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.Be2 Nc6 8.O-O O-O 9.Nb3
+B75 "Sicilian Defense: Dragon Variation, Yugoslav Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.f3
+B76 "Sicilian Defense: Dragon Variation, Yugoslav Attack, 7...O-O" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.f3 O-O
+B77 "Sicilian Defense: Dragon Variation, Yugoslav Attack, 9.Bc4" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.f3 O-O 8.Qd2 Nc6 9.Bc4
+B78 "Sicilian Defense: Dragon Variation, Yugoslav Attack, 10.O-O-O" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.f3 O-O 8.Qd2 Nc6 9.Bc4 Bd7 10.O-O-O
+B79 "Sicilian Defense: Dragon Variation, Yugoslav Attack, 12.h4" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 g6 6.Be3 Bg7 7.f3 O-O 8.Qd2 Nc6 9.Bc4 Bd7 10.O-O-O Qa5 11.Bb3 Rfc8 12.h4
+B80 "Sicilian Defense: Scheveningen Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6
+B80 "Sicilian Defense: Scheveningen Variation, English Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.Be3 a6 7.f3
+B81 "Sicilian Defense: Scheveningen Variation, Keres Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.g4
+B82 "Sicilian Defense: Scheveningen Variation, Matanovic Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.f4
+B83 "Sicilian Defense: Scheveningen Variation, Modern Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.Be2
+B84 "Sicilian Defense: Scheveningen Variation, Classical Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.Be2 a6
+B85 "Sicilian Defense: Scheveningen Variation, Classical Main Line" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 e6 6.Be2 a6 7.O-O Qc7 8.f4 Nc6
+B86 "Sicilian Defense: Sozin Attack, Flank Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bc4 e6
+B87 "Sicilian Defense: Sozin Attack, Flank Variation, 7...b5" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bc4 e6 7.Bb3 b5
+B88 "Sicilian Defense: Sozin Attack, Leonhardt Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bc4 e6 7.Bb3
+B89 "Sicilian Defense: Velimirovic Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 Nc6 6.Bc4 e6 7.Be3
+B90 "Sicilian Defense: Najdorf Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6
+B90 "Sicilian Defense: Najdorf Variation, English Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Be3
+B90 "Sicilian Defense: Najdorf Variation, Adams Attack" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.h3
+B91 "Sicilian Defense: Najdorf Variation, Zagreb Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.g3
+B92 "Sicilian Defense: Najdorf Variation, Opocensky Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Be2
+B93 "Sicilian Defense: Najdorf Variation, Amsterdam Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.f4
+B94 "Sicilian Defense: Najdorf Variation, 6.Bg5" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5
+B95 "Sicilian Defense: Najdorf Variation, 6...e6" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5 e6
+B96 "Sicilian Defense: Najdorf Variation, 7.f4" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5 e6 7.f4
+B97 "Sicilian Defense: Najdorf Variation, Poisoned Pawn Variation" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5 e6 7.f4 Qb6
+B98 "Sicilian Defense: Najdorf Variation, 7...Be7" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5 e6 7.f4 Be7
+B99 "Sicilian Defense: Najdorf Variation, Main Line" 1.e4 c5 2.Nf3 d6 3.d4 cxd4 4.Nxd4 Nf6 5.Nc3 a6 6.Bg5 e6 7.f4 Be7 8.Qf3 Qc7 9.O-O-O Nbd7
+C00 "French Defense" 1.e4 e6
+C00 "French Defense: Normal Variation" 1.e4 e6 2.d4
+C00 "French Defense: King's Indian Attack" 1.e4 e6 2.d3
+C00 "French Defense: Chigorin Variation" 1.e4 e6 2.Qe2
+C01 "French Defense: Exchange Variation" 1.e4 e6 2.d4 d5 3.exd5
+C02 "French Defense: Advance Variation" 1.e4 e6 2.d4 d5 3.e5
+C02 "French Defense: Advance Variation, Main Line" 1.e4 e6 2.d4 d5 3.e5 c5 4.c3 Nc6 5.Nf3 Qb6
+C03 "French Defense: Tarrasch Variation" 1.e4 e6 2.d4 d5 3.Nd2
+C03 "French Defense: Tarrasch Variation, Guimard Defense" 1.e4 e6 2.d4 d5 3.Nd2 Nc6
+C04 "French Defense: Tarrasch Variation, Guimard Defense, Main Line" 1.e4 e6 2.d4 d5 3.Nd2 Nc6 4.Ngf3 Nf6
+C05 "French Defense: Tarrasch Variation, Closed Variation" 1.e4 e6 2.d4 d5 3.Nd2 Nf6
+C06 "French Defense: Tarrasch Variation, Closed Variation, Main Line" 1.e4 e6 2.d4 d5 3.Nd2 Nf6 4.e5 Nfd7 5.Bd3 c5 6.c3 Nc6 7.Ne2 cxd4 8.cxd4
+C07 "French Defense: Tarrasch Variation, Open System" 1.e4 e6 2.d4 d5 3.Nd2 c5
+C08 "French Defense: Tarrasch Variation, Open System, Euwe-Keres Line" 1.e4 e6 2.d4 d5 3.Nd2 c5 4.exd5 exd5
+C09 "French Defense: Tarrasch Variation, Open System, Main Line" 1.e4 e6 2.d4 d5 3.Nd2 c5 4.exd5 exd5 5.Ngf3 Nc6
+C10 "French Defense: Paulsen Variation" 1.e4 e6 2.d4 d5 3.Nc3
+C10 "French Defense: Rubinstein Variation" 1.e4 e6 2.d4 d5 3.Nc3 dxe4
+C11 "French Defense: Classical Variation" 1.e4 e6 2.d4 d5 3.Nc3 Nf6
+C11 "French Defense: Steinitz Variation" 1.e4 e6 2.d4 d5 3.Nc3 Nf6 4.e5
+C12 "French Defense: MacCutcheon Variation" 1.e4 e6 2.d4 d5 3.Nc3 Nf6 4.Bg5 Bb4
+C13 "French Defense: Classical Variation, Normal Variation" 1.e4 e6 2.d4 d5 3.Nc3 Nf6 4.Bg5 Be7
+C13 "French Defense: Alekhine-Chatard Attack" 1.e4 e6 2.d4 d5 3.Nc3 Nf6 4.Bg5 Be7 5.e5 Nfd7 6.h4
+C14 "French Defense: Classical Variation, Main Line" 1.e4 e6 2.d4 d5 3.Nc3 Nf6 4.Bg5 Be7 5.e5 Nfd7 6.Bxe7 Qxe7
+C15 "French Defense: Winawer Variation" 1.e4 e6 2.d4 d5 3.Nc3 Bb4
+C16 "French Defense: Winawer Variation, Advance Variation" 1.e4 e6 2.d4 d5 3.Nc3 Bb4 4.e5
+C17 "French Defense: Winawer Variation, Bogoljubov Variation" 1.e4 e6 2.d4 d5 3.Nc3 Bb4 4.e5 c5
+C18 "French Defense: Winawer Variation, Classical Variation" 1.e4 e6 2.d4 d5 3.Nc3 Bb4 4.e5 c5 5.a3 Bxc3+ 6.bxc3
+C19 "French Defense: Winawer Variation, Advance Variation, 6...Ne7" 1.e4 e6 2.d4 d5 3.Nc3 Bb4 4.e5 c5 5.a3 Bxc3+ 6.bxc3 Ne7
+C20 "King's Pawn Game" 1.e4 e5
+C20 "King's Pawn Game: Wayward Queen Attack" 1.e4 e5 2.Qh5
+C20 "Portuguese Opening" 1.e4 e5 2.Bb5
+C20 "King's Pawn Game: Napoleon Attack" 1.e4 e5 2.Qf3
+C20 "Alapin Opening" 1.e4 e5 2.Ne2
+C21 "Center Game" 1.e4 e5 2.d4 exd4
+C21 "Danish Gambit" 1.e4 e5 2.d4 exd4 3.c3
+C21 "Danish Gambit Accepted" 1.e4 e5 2.d4 exd4 3.c3 dxc3 4.Bc4
+C22 "Center Game: Accepted" 1.e4 e5 2.d4 exd4 3.Qxd4 Nc6
+C23 "Bishop's Opening" 1.e4 e5 2.Bc4
+C24 "Bishop's Opening: Berlin Defense" 1.e4 e5 2.Bc4 Nf6
+C25 "Vienna Game" 1.e4 e5 2.Nc3
+C25 "Vienna Game: Max Lange Defense" 1.e4 e5 2.Nc3 Nc6
+C25 "Vienna Game: Vienna Gambit" 1.e4 e5 2.Nc3 Nc6 3.f4
+C26 "Vienna Game: Falkbeer Variation" 1.e4 e5 2.Nc3 Nf6
+C26 "Vienna Game: Stanley Variation" 1.e4 e5 2.Nc3 Nf6 3.Bc4
+C27 "Vienna Game: Frankenstein-Dracula Variation" 1.e4 e5 2.Nc3 Nf6 3.Bc4 Nxe4
+C28 "Vienna Game: Stanley Variation, Three Knights Variation" 1.e4 e5 2.Nc3 Nf6 3.Bc4 Nc6
+C29 "Vienna Game: Vienna Gambit" 1.e4 e5 2.Nc3 Nf6 3.f4
+C30 "King's Gambit" 1.e4 e5 2.f4
+C30 "King's Gambit Declined: Classical Variation" 1.e4 e5 2.f4 Bc5
+C31 "King's Gambit Declined: Falkbeer Countergambit" 1.e4 e5 2.f4 d5
+C32 "King's Gambit Declined: Falkbeer Countergambit, Morphy Gambit" 1.e4 e5 2.f4 d5 3.exd5 e4 4.d3 Nf6 5.dxe4
+C33 "King's Gambit Accepted" 1.e4 e5 2.f4 exf4
+C33 "King's Gambit Accepted: Bishop's Gambit" 1.e4 e5 2.f4 exf4 3.Bc4
+C34 "King's Gambit Accepted: King's Knight Gambit" 1.e4 e5 2.f4 exf4 3.Nf3
+C34 "King's Gambit Accepted: Fischer Defense" 1.e4 e5 2.f4 exf4 3.Nf3 d6
+C35 "King's Gambit Accepted: Cunningham Defense" 1.e4 e5 2.f4 exf4 3.Nf3 Be7
+C36 "King's Gambit Accepted: Modern Defense" 1.e4 e5 2.f4 exf4 3.Nf3 d5
+C37 "King's Gambit Accepted: Quaade Gambit" 1.e4 e5 2.f4 exf4 3.Nf3 g5 4.Nc3
+C37 "King's Gambit Accepted: Muzio Gambit" 1.e4 e5 2.f4 exf4 3.Nf3 g5 4.Bc4 g4 5.O-O
+C38 "King's Gambit Accepted: Hanstein Gambit" 1.e4 e5 2.f4 exf4 3.Nf3 g5 4.Bc4 Bg7
+C39 "King's Gambit Accepted: Kieseritzky Gambit" 1.e4 e5 2.f4 exf4 3.Nf3 g5 4.h4
+C40 "King's Knight Opening" 1.e4 e5 2.Nf3
+C40 "Latvian Gambit" 1.e4 e5 2.Nf3 f5
+C40 "Elephant Gambit" 1.e4 e5 2.Nf3 d5
+C41 "Philidor Defense" 1.e4 e5 2.Nf3 d6
+C41 "Philidor Defense: Exchange Variation" 1.e4 e5 2.Nf3 d6 3.d4 exd4
+C41 "Philidor Defense: Hanham Variation" 1.e4 e5 2.Nf3 d6 3.d4 Nd7
+C42 "Petrov's Defense" 1.e4 e5 2.Nf3 Nf6
+C42 "Petrov's Defense: Classical Attack" 1.e4 e5 2.Nf3 Nf6 3.Nxe5 d6 4.Nf3 Nxe4 5.d4
+C42 "Petrov's Defense: Nimzowitsch Attack" 1.e4 e5 2.Nf3 Nf6 3.Nxe5 d6 4.Nf3 Nxe4 5.Nc3
+C42 "Petrov's Defense: Three Knights Game" 1.e4 e5 2.Nf3 Nf6 3.Nc3
+C43 "Petrov's Defense: Modern Attack" 1.e4 e5 2.Nf3 Nf6 3.d4
+C44 "King's Pawn Game" 1.e4 e5 2.Nf3 Nc6
+C44 "Ponziani Opening" 1.e4 e5 2.Nf3 Nc6 3.c3
+C44 "Scotch Game" 1.e4 e5 2.Nf3 Nc6 3.d4
+C44 "Scotch Game: Scotch Gambit" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.Bc4
+C44 "Scotch Game: Göring Gambit" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.c3
+C45 "Scotch Game" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.Nxd4
+C45 "Scotch Game: Classical Variation" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.Nxd4 Bc5
+C45 "Scotch Game: Schmidt Variation" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.Nxd4 Nf6
+C45 "Scotch Game: Mieses Variation" 1.e4 e5 2.Nf3 Nc6 3.d4 exd4 4.Nxd4 Nf6 5.Nxc6 bxc6 6.e5
+C46 "Three Knights Opening" 1.e4 e5 2.Nf3 Nc6 3.Nc3
+C47 "Four Knights Game" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6
+C47 "Four Knights Game: Scotch Variation" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6 4.d4
+C47 "Four Knights Game: Italian Variation" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6 4.Bc4
+C48 "Four Knights Game: Spanish Variation" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6 4.Bb5
+C48 "Four Knights Game: Spanish Variation, Rubinstein Variation" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6 4.Bb5 Nd4
+C49 "Four Knights Game: Double Spanish" 1.e4 e5 2.Nf3 Nc6 3.Nc3 Nf6 4.Bb5 Bb4
+C50 "Italian Game" 1.e4 e5 2.Nf3 Nc6 3.Bc4
+C50 "Italian Game: Giuoco Piano" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5
+C50 "Italian Game: Giuoco Pianissimo" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.d3
+C50 "Italian Game: Hungarian Defense" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Be7
+C50 "Italian Game: Blackburne-Kostić Gambit" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nd4
+C51 "Italian Game: Evans Gambit" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.b4
+C51 "Italian Game: Evans Gambit Declined" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.b4 Bb6
+C52 "Italian Game: Evans Gambit, Main Line" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.b4 Bxb4 5.c3 Ba5
+C53 "Italian Game: Classical Variation" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.c3
+C53 "Italian Game: Classical Variation, Giuoco Pianissimo" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.c3 Nf6 5.d3
+C54 "Italian Game: Classical Variation, Center Attack" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.c3 Nf6 5.d4 exd4 6.cxd4
+C54 "Italian Game: Classical Variation, Greco Gambit" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Bc5 4.c3 Nf6 5.d4 exd4 6.cxd4 Bb4+ 7.Nc3
+C55 "Italian Game: Two Knights Defense" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6
+C55 "Italian Game: Two Knights Defense, Modern Bishop's Opening" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.d3
+C55 "Italian Game: Scotch Gambit" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.d4
+C56 "Italian Game: Scotch Gambit, Canal Variation" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.d4 exd4 5.O-O Nxe4
+C57 "Italian Game: Two Knights Defense, Knight Attack" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.Ng5
+C57 "Italian Game: Two Knights Defense, Traxler Counterattack" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.Ng5 Bc5
+C57 "Italian Game: Two Knights Defense, Fried Liver Attack" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.Ng5 d5 5.exd5 Nxd5 6.Nxf7
+C58 "Italian Game: Two Knights Defense, Polerio Defense" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.Ng5 d5 5.exd5 Na5
+C59 "Italian Game: Two Knights Defense, Polerio Defense, Goring Variation" 1.e4 e5 2.Nf3 Nc6 3.Bc4 Nf6 4.Ng5 d5 5.exd5 Na5 6.Bb5+ c6 7.dxc6 bxc6 8.Be2 h6
+C60 "Ruy Lopez" 1.e4 e5 2.Nf3 Nc6 3.Bb5
+C60 "Ruy Lopez: Cozio Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nge7
+C60 "Ruy Lopez: Fianchetto Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 g6
+C61 "Ruy Lopez: Bird Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nd4
+C62 "Ruy Lopez: Steinitz Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 d6
+C63 "Ruy Lopez: Schliemann Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 f5
+C64 "Ruy Lopez: Classical Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Bc5
+C65 "Ruy Lopez: Berlin Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6
+C65 "Ruy Lopez: Berlin Defense, Anderssen Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6 4.d3
+C66 "Ruy Lopez: Berlin Defense, Improved Steinitz Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6 4.O-O d6
+C67 "Ruy Lopez: Berlin Defense, Rio Gambit Accepted" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6 4.O-O Nxe4
+C67 "Ruy Lopez: Berlin Defense, l'Hermet Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6 4.O-O Nxe4 5.d4 Nd6
+C67 "Ruy Lopez: Berlin Defense, Berlin Wall" 1.e4 e5 2.Nf3 Nc6 3.Bb5 Nf6 4.O-O Nxe4 5.d4 Nd6 6.Bxc6 dxc6 7.dxe5 Nf5 8.Qxd8+ Kxd8
+C68 "Ruy Lopez: Exchange Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Bxc6
+C68 "Ruy Lopez: Morphy Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6
+C69 "Ruy Lopez: Exchange Variation, Normal Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Bxc6 dxc6 5.O-O
+C70 "Ruy Lopez: Morphy Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4
+C71 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6
+C72 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense, 5.O-O" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6 5.O-O
+C73 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense, Richter Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6 5.Bxc6+ bxc6 6.d4
+C74 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense, 5.c3" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6 5.c3
+C75 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense, 5...Bd7" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6 5.c3 Bd7
+C76 "Ruy Lopez: Morphy Defense, Modern Steinitz Defense, Fianchetto Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 d6 5.c3 Bd7 6.d4 g6
+C77 "Ruy Lopez: Morphy Defense, Anderssen Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6
+C77 "Ruy Lopez: Morphy Defense, Wormald Attack" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.Qe2
+C78 "Ruy Lopez: Morphy Defense, 5.O-O" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O
+C78 "Ruy Lopez: Archangel Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O b5 6.Bb3 Bb7
+C78 "Ruy Lopez: Møller Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Bc5
+C79 "Ruy Lopez: Steinitz Defense Deferred" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O d6
+C80 "Ruy Lopez: Open Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4
+C80 "Ruy Lopez: Open Variation, Main Line" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4 6.d4 b5 7.Bb3 d5 8.dxe5 Be6
+C81 "Ruy Lopez: Open Variation, Howell Attack" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4 6.d4 b5 7.Bb3 d5 8.dxe5 Be6 9.Qe2
+C82 "Ruy Lopez: Open Variation, 9.c3" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4 6.d4 b5 7.Bb3 d5 8.dxe5 Be6 9.c3
+C82 "Ruy Lopez: Open Variation, Dilworth Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4 6.d4 b5 7.Bb3 d5 8.dxe5 Be6 9.c3 Bc5 10.Nbd2 O-O 11.Bc2 Nxf2
+C83 "Ruy Lopez: Open Variation, Classical Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Nxe4 6.d4 b5 7.Bb3 d5 8.dxe5 Be6 9.c3 Be7
+C84 "Ruy Lopez: Closed" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7
+C85 "Ruy Lopez: Closed, Delayed Exchange" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Bxc6
+C86 "Ruy Lopez: Worrall Attack" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Qe2
+C87 "Ruy Lopez: Closed, Averbakh Variation" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 d6
+C88 "Ruy Lopez: Closed" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3
+C88 "Ruy Lopez: Closed, Anti-Marshall" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 O-O 8.a4
+C88 "Ruy Lopez: Closed, 8.h3" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 O-O 8.h3
+C89 "Ruy Lopez: Marshall Attack" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 O-O 8.c3 d5
+C89 "Ruy Lopez: Marshall Attack, Main Line" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 O-O 8.c3 d5 9.exd5 Nxd5 10.Nxe5 Nxe5 11.Rxe5 c6
+C90 "Ruy Lopez: Closed, 7...d6" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6
+C90 "Ruy Lopez: Closed, 8.c3" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O
+C91 "Ruy Lopez: Closed, 9.d4" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.d4
+C92 "Ruy Lopez: Closed, 9.h3" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3
+C92 "Ruy Lopez: Closed, Zaitsev System" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Bb7
+C93 "Ruy Lopez: Closed, Smyslov Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 h6
+C94 "Ruy Lopez: Closed, Breyer Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Nb8
+C95 "Ruy Lopez: Closed, Breyer Defense, 10.d4" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Nb8 10.d4
+C96 "Ruy Lopez: Closed, Chigorin Defense" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Na5 10.Bc2
+C97 "Ruy Lopez: Closed, Chigorin Defense, Main Line" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Na5 10.Bc2 c5 11.d4 Qc7
+C98 "Ruy Lopez: Closed, Chigorin Defense, Rauzer Attack" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Na5 10.Bc2 c5 11.d4 Qc7 12.Nbd2 Nc6
+C99 "Ruy Lopez: Closed, Chigorin Defense, Panov System" 1.e4 e5 2.Nf3 Nc6 3.Bb5 a6 4.Ba4 Nf6 5.O-O Be7 6.Re1 b5 7.Bb3 d6 8.c3 O-O 9.h3 Na5 10.Bc2 c5 11.d4 Qc7 12.Nbd2 cxd4 13.cxd4
+D00 "Queen's Pawn Game" 1.d4 d5
+D00 "Blackmar-Diemer Gambit" 1.d4 d5 2.e4
+D00 "Queen's Pawn Game: Levitsky Attack" 1.d4 d5 2.Bg5
+D00 "Queen's Pawn Game: Accelerated London System" 1.d4 d5 2.Bf4
+D00 "Queen's Pawn Game: Stonewall Attack" 1.d4 d5 2.e3 Nf6 3.Bd3
+D01 "Richter-Veresov Attack" 1.d4 d5 2.Nc3 Nf6 3.Bg5
+D02 "Queen's Pawn Game: Zukertort Variation" 1.d4 d5 2.Nf3
+D02 "Queen's Pawn Game: London System" 1.d4 d5 2.Nf3 Nf6 3.Bf4
+D03 "Queen's Pawn Game: Torre Attack" 1.d4 d5 2.Nf3 Nf6 3.Bg5
+D04 "Queen's Pawn Game: Colle System" 1.d4 d5 2.Nf3 Nf6 3.e3
+D05 "Queen's Pawn Game: Colle System, Anti-Colle" 1.d4 d5 2.Nf3 Nf6 3.e3 e6
+D05 "Queen's Pawn Game: Zukertort Variation" 1.d4 d5 2.Nf3 Nf6 3.e3 e6 4.Bd3 c5 5.b3
+D06 "Queen's Gambit" 1.d4 d5 2.c4
+D06 "Queen's Gambit Declined: Baltic Defense" 1.d4 d5 2.c4 Bf5
+D06 "Queen's Gambit Declined: Marshall Defense" 1.d4 d5 2.c4 Nf6
+D07 "Queen's Gambit Declined: Chigorin Defense" 1.d4 d5 2.c4 Nc6
+D08 "Queen's Gambit Declined: Albin Countergambit" 1.d4 d5 2.c4 e5
+D08 "Queen's Gambit Declined: Albin Countergambit, Lasker Trap" 1.d4 d5 2.c4 e5 3.dxe5 d4 4.e3 Bb4+ 5.Bd2 dxe3
+D09 "Queen's Gambit Declined: Albin Countergambit, Fianchetto Variation" 1.d4 d5 2.c4 e5 3.dxe5 d4 4.Nf3 Nc6 5.g3
+D10 "Slav Defense" 1.d4 d5 2.c4 c6
+D10 "Slav Defense: Exchange Variation" 1.d4 d5 2.c4 c6 3.cxd5
+D11 "Slav Defense: Modern Line" 1.d4 d5 2.c4 c6 3.Nf3
+D12 "Slav Defense: Quiet Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.e3 Bf5
+D13 "Slav Defense: Exchange Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.cxd5 cxd5
+D14 "Slav Defense: Exchange Variation, Symmetrical Line" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.cxd5 cxd5 5.Nc3 Nc6 6.Bf4 Bf5
+D15 "Slav Defense: Three Knights Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3
+D15 "Slav Defense: Chameleon Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3 a6
+D16 "Slav Defense: Alapin Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3 dxc4 5.a4
+D17 "Slav Defense: Czech Variation" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3 dxc4 5.a4 Bf5
+D18 "Slav Defense: Czech Variation, Classical System" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3 dxc4 5.a4 Bf5 6.e3
+D19 "Slav Defense: Czech Variation, Main Line" 1.d4 d5 2.c4 c6 3.Nf3 Nf6 4.Nc3 dxc4 5.a4 Bf5 6.e3 e6 7.Bxc4 Bb4 8.O-O
+D20 "Queen's Gambit Accepted" 1.d4 d5 2.c4 dxc4
+D20 "Queen's Gambit Accepted: Central Variation" 1.d4 d5 2.c4 dxc4 3.e4
+D21 "Queen's Gambit Accepted: Normal Variation" 1.d4 d5 2.c4 dxc4 3.Nf3
+D22 "Queen's Gambit Accepted: Alekhine Defense" 1.d4 d5 2.c4 dxc4 3.Nf3 a6
+D23 "Queen's Gambit Accepted: Main Line" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6
+D24 "Queen's Gambit Accepted: Bogoljubov Defense" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.Nc3
+D25 "Queen's Gambit Accepted: Old Variation" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.e3
+D26 "Queen's Gambit Accepted: Classical Defense" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.e3 e6
+D27 "Queen's Gambit Accepted: Classical Defense, Main Line" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.e3 e6 5.Bxc4 c5 6.O-O a6
+D28 "Queen's Gambit Accepted: Classical Defense, 7.Qe2" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.e3 e6 5.Bxc4 c5 6.O-O a6 7.Qe2
+D29 "Queen's Gambit Accepted: Classical Defense, 8...Bb7" 1.d4 d5 2.c4 dxc4 3.Nf3 Nf6 4.e3 e6 5.Bxc4 c5 6.O-O a6 7.Qe2 b5 8.Bb3 Bb7
+D30 "Queen's Gambit Declined" 1.d4 d5 2.c4 e6
+D30 "Queen's Gambit Declined: Normal Defense" 1.d4 d5 2.c4 e6 3.Nf3 Nf6
+D31 "Queen's Gambit Declined: Queen's Knight Variation" 1.d4 d5 2.c4 e6 3.Nc3
+D31 "Semi-Slav Defense: Noteboom Variation" 1.d4 d5 2.c4 e6 3.Nc3 c6 4.Nf3 dxc4
+D31 "Queen's Gambit Declined: Alatortsev Variation" 1.d4 d5 2.c4 e6 3.Nc3 Be7
+D32 "Tarrasch Defense" 1.d4 d5 2.c4 e6 3.Nc3 c5
+D33 "Tarrasch Defense: Prague Variation" 1.d4 d5 2.c4 e6 3.Nc3 c5 4.cxd5 exd5 5.Nf3 Nc6 6.g3
+D34 "Tarrasch Defense: Prague Variation, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 c5 4.cxd5 exd5 5.Nf3 Nc6 6.g3 Nf6 7.Bg2 Be7
+D35 "Queen's Gambit Declined: Exchange Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.cxd5
+D35 "Queen's Gambit Declined: Normal Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6
+D36 "Queen's Gambit Declined: Exchange Variation, Reshevsky Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.cxd5 exd5 5.Bg5 c6 6.Qc2
+D37 "Queen's Gambit Declined: Three Knights Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3
+D37 "Queen's Gambit Declined: Harrwitz Attack" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 Be7 5.Bf4
+D38 "Queen's Gambit Declined: Ragozin Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 Bb4
+D39 "Queen's Gambit Declined: Ragozin Defense, Vienna Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 Bb4 5.Bg5 dxc4
+D40 "Queen's Gambit Declined: Semi-Tarrasch Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c5
+D41 "Queen's Gambit Declined: Semi-Tarrasch Defense, Exchange Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c5 5.cxd5
+D42 "Queen's Gambit Declined: Semi-Tarrasch Defense, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c5 5.cxd5 Nxd5 6.e3 Nc6 7.Bd3
+D43 "Semi-Slav Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6
+D43 "Semi-Slav Defense: Moscow Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.Bg5 h6
+D44 "Semi-Slav Defense: Botvinnik System" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.Bg5 dxc4
+D45 "Semi-Slav Defense: Normal Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3
+D45 "Semi-Slav Defense: Anti-Meran Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3 Nbd7 6.Qc2
+D46 "Semi-Slav Defense: Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3 Nbd7 6.Bd3
+D47 "Semi-Slav Defense: Meran Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3 Nbd7 6.Bd3 dxc4 7.Bxc4
+D48 "Semi-Slav Defense: Meran Variation, Pirc Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3 Nbd7 6.Bd3 dxc4 7.Bxc4 b5 8.Bd3 a6
+D49 "Semi-Slav Defense: Meran Variation, Blumenfeld Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Nf3 c6 5.e3 Nbd7 6.Bd3 dxc4 7.Bxc4 b5 8.Bd3 a6 9.e4 c5 10.e5 cxd4 11.Nxb5
+D50 "Queen's Gambit Declined: Modern Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5
+D51 "Queen's Gambit Declined: Modern Variation, Knight Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Nbd7
+D52 "Queen's Gambit Declined: Cambridge Springs Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Nbd7 5.e3 c6 6.Nf3 Qa5
+D52 "Queen's Gambit Declined" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Nbd7 5.e3 c6 6.Nf3
+D53 "Queen's Gambit Declined: Modern Variation, Heral Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7
+D54 "Queen's Gambit Declined: Anti-Tartakower Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Rc1
+D55 "Queen's Gambit Declined: Pillsbury Attack" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3
+D56 "Queen's Gambit Declined: Lasker Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 h6 7.Bh4 Ne4
+D57 "Queen's Gambit Declined: Lasker Defense, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 h6 7.Bh4 Ne4 8.Bxe7 Qxe7 9.cxd5 Nxc3 10.bxc3
+D58 "Queen's Gambit Declined: Tartakower Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 h6 7.Bh4 b6
+D59 "Queen's Gambit Declined: Tartakower Defense, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 h6 7.Bh4 b6 8.cxd5 Nxd5
+D60 "Queen's Gambit Declined: Orthodox Defense" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7
+D61 "Queen's Gambit Declined: Orthodox Defense, Rubinstein Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Qc2
+D62 "Queen's Gambit Declined: Orthodox Defense, Rubinstein Variation, Flohr Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Qc2 c5 8.cxd5
+D63 "Queen's Gambit Declined: Orthodox Defense, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1
+D64 "Queen's Gambit Declined: Orthodox Defense, Rubinstein Attack" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Qc2
+D65 "Queen's Gambit Declined: Orthodox Defense, Rubinstein Attack, Main Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Qc2 a6 9.cxd5
+D66 "Queen's Gambit Declined: Orthodox Defense, Bd3 Line" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Bd3
+D67 "Queen's Gambit Declined: Orthodox Defense, Capablanca Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Bd3 dxc4 9.Bxc4 Nd5
+D68 "Queen's Gambit Declined: Orthodox Defense, Classical Variation" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Bd3 dxc4 9.Bxc4 Nd5 10.Bxe7 Qxe7 11.O-O Nxc3 12.Rxc3 e5
+D69 "Queen's Gambit Declined: Orthodox Defense, Classical, 13.dxe5" 1.d4 d5 2.c4 e6 3.Nc3 Nf6 4.Bg5 Be7 5.e3 O-O 6.Nf3 Nbd7 7.Rc1 c6 8.Bd3 dxc4 9.Bxc4 Nd5 10.Bxe7 Qxe7 11.O-O Nxc3 12.Rxc3 e5 13.dxe5 Nxe5 14.Nxe5 Qxe5
+D70 "Neo-Grünfeld Defense" 1.d4 Nf6 2.c4 g6 3.f3 d5
+D71 "Neo-Grünfeld Defense: Exchange Variation" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.cxd5 Nxd5
+D72 "Neo-Grünfeld Defense: Exchange Variation, Main Line" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.cxd5 Nxd5 6.e4 Nb6 7.Ne2
+D73 "Neo-Grünfeld Defense: Goglidze Attack" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3
+D74 "Neo-Grünfeld Defense: Delayed Exchange Variation" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.cxd5 Nxd5 7.O-O
+D75 "Neo-Grünfeld Defense: Delayed Exchange Variation, 7...c5" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.cxd5 Nxd5 7.O-O c5 8.Nc3
+D76 "Neo-Grünfeld Defense: Delayed Exchange Variation, 7...Nb6" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.cxd5 Nxd5 7.O-O Nb6
+D77 "Neo-Grünfeld Defense: Classical Variation" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.O-O
+D78 "Neo-Grünfeld Defense: Classical Variation, Original Defense" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.O-O c6
+D79 "Neo-Grünfeld Defense: Ultra-Delayed Exchange Variation" 1.d4 Nf6 2.c4 g6 3.g3 d5 4.Bg2 Bg7 5.Nf3 O-O 6.O-O c6 7.cxd5 cxd5
+D80 "Grünfeld Defense" 1.d4 Nf6 2.c4 g6 3.Nc3 d5
+D80 "Grünfeld Defense: Stockholm Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Bg5
+D81 "Grünfeld Defense: Russian Variation, Accelerated Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Qb3
+D82 "Grünfeld Defense: Brinckmann Attack" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Bf4
+D83 "Grünfeld Defense: Brinckmann Attack, Grünfeld Gambit" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Bf4 Bg7 5.e3 O-O
+D84 "Grünfeld Defense: Grünfeld Gambit Accepted" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Bf4 Bg7 5.e3 O-O 6.cxd5 Nxd5 7.Nxd5 Qxd5 8.Bxc7
+D85 "Grünfeld Defense: Exchange Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5
+D85 "Grünfeld Defense: Modern Exchange Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5 5.e4 Nxc3 6.bxc3 Bg7 7.Nf3
+D86 "Grünfeld Defense: Exchange Variation, Classical Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5 5.e4 Nxc3 6.bxc3 Bg7 7.Bc4
+D87 "Grünfeld Defense: Exchange Variation, Spassky Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5 5.e4 Nxc3 6.bxc3 Bg7 7.Bc4 O-O 8.Ne2 c5
+D88 "Grünfeld Defense: Exchange Variation, Spassky Variation, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5 5.e4 Nxc3 6.bxc3 Bg7 7.Bc4 O-O 8.Ne2 c5 9.O-O Nc6 10.Be3 cxd4 11.cxd4
+D89 "Grünfeld Defense: Exchange Variation, Sokolsky Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.cxd5 Nxd5 5.e4 Nxc3 6.bxc3 Bg7 7.Bc4 O-O 8.Ne2 c5 9.O-O Nc6 10.Be3 cxd4 11.cxd4 Bg4 12.f3 Na5 13.Bd3 Be6
+D90 "Grünfeld Defense: Three Knights Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3
+D91 "Grünfeld Defense: Three Knights Variation, Petrosian System" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Bg5
+D92 "Grünfeld Defense: Three Knights Variation, Hungarian Attack" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Bf4
+D93 "Grünfeld Defense: Three Knights Variation, Hungarian Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Bf4 O-O 6.e3
+D94 "Grünfeld Defense: Three Knights Variation, Paris Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.e3
+D95 "Grünfeld Defense: Three Knights Variation, Vienna Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.e3 O-O 6.Qb3
+D96 "Grünfeld Defense: Russian Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Qb3
+D97 "Grünfeld Defense: Russian Variation, 7.e4" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Qb3 dxc4 6.Qxc4 O-O 7.e4
+D97 "Grünfeld Defense: Russian Variation, Hungarian Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Qb3 dxc4 6.Qxc4 O-O 7.e4 a6
+D98 "Grünfeld Defense: Russian Variation, Smyslov Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Qb3 dxc4 6.Qxc4 O-O 7.e4 Bg4
+D99 "Grünfeld Defense: Russian Variation, Smyslov Variation, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 d5 4.Nf3 Bg7 5.Qb3 dxc4 6.Qxc4 O-O 7.e4 Bg4 8.Be3 Nfd7 9.Qb3
+E00 "Indian Defense: East Indian Defense" 1.d4 Nf6 2.c4 e6
+E00 "Catalan Opening" 1.d4 Nf6 2.c4 e6 3.g3
+E00 "Indian Defense: Seirawan Attack" 1.d4 Nf6 2.c4 e6 3.Bg5
+E01 "Catalan Opening: Closed" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2
+E02 "Catalan Opening: Open Defense" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 dxc4 5.Qa4+
+E03 "Catalan Opening: Open Defense, Alekhine Variation" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 dxc4 5.Qa4+ Nbd7 6.Qxc4
+E04 "Catalan Opening: Open Defense" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 dxc4 5.Nf3
+E05 "Catalan Opening: Open Defense, Classical Line" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 dxc4 5.Nf3 Be7
+E06 "Catalan Opening: Closed Variation" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 Be7 5.Nf3
+E07 "Catalan Opening: Closed, 6...Nbd7" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 Be7 5.Nf3 O-O 6.O-O Nbd7
+E08 "Catalan Opening: Closed, 7.Qc2" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 Be7 5.Nf3 O-O 6.O-O Nbd7 7.Qc2
+E09 "Catalan Opening: Closed, Main Line" 1.d4 Nf6 2.c4 e6 3.g3 d5 4.Bg2 Be7 5.Nf3 O-O 6.O-O Nbd7 7.Qc2 c6 8.Nbd2
+E10 "Indian Defense: Anti-Nimzo-Indian" 1.d4 Nf6 2.c4 e6 3.Nf3
+E10 "Blumenfeld Countergambit" 1.d4 Nf6 2.c4 e6 3.Nf3 c5 4.d5 b5
+E11 "Bogo-Indian Defense" 1.d4 Nf6 2.c4 e6 3.Nf3 Bb4+
+E11 "Bogo-Indian Defense: Grünfeld Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 Bb4+ 4.Nbd2
+E11 "Bogo-Indian Defense: Exchange Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 Bb4+ 4.Bd2 Bxd2+
+E12 "Queen's Indian Defense" 1.d4 Nf6 2.c4 e6 3.Nf3 b6
+E12 "Queen's Indian Defense: Petrosian Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.a3
+E13 "Queen's Indian Defense: Kasparov Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.Nc3 Bb7 5.Bg5 h6 6.Bh4 Bb4
+E14 "Queen's Indian Defense: Spassky System" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.e3
+E15 "Queen's Indian Defense: Fianchetto Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3
+E15 "Queen's Indian Defense: Fianchetto Variation, Nimzowitsch Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3 Ba6
+E16 "Queen's Indian Defense: Capablanca Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3 Bb7 5.Bg2 Bb4+
+E17 "Queen's Indian Defense: Fianchetto Variation, 5...Be7" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3 Bb7 5.Bg2 Be7
+E18 "Queen's Indian Defense: Fianchetto Variation, Traditional Variation" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3 Bb7 5.Bg2 Be7 6.O-O O-O 7.Nc3
+E19 "Queen's Indian Defense: Fianchetto Variation, Traditional Variation, Main Line" 1.d4 Nf6 2.c4 e6 3.Nf3 b6 4.g3 Bb7 5.Bg2 Be7 6.O-O O-O 7.Nc3 Ne4 8.Qc2 Nxc3 9.Qxc3
+E20 "Nimzo-Indian Defense" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4
+E20 "Nimzo-Indian Defense: Kmoch Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.f3
+E21 "Nimzo-Indian Defense: Three Knights Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Nf3
+E22 "Nimzo-Indian Defense: Spielmann Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qb3
+E23 "Nimzo-Indian Defense: Spielmann Variation, Karlsbad Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qb3 c5 5.dxc5 Nc6
+E24 "Nimzo-Indian Defense: Sämisch Variation, Accelerated" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3
+E25 "Nimzo-Indian Defense: Sämisch Variation, Keres Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3 c5 6.f3 d5 7.cxd5
+E26 "Nimzo-Indian Defense: Sämisch Variation, O'Kelly Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3 c5 6.e3
+E27 "Nimzo-Indian Defense: Sämisch Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3 O-O
+E28 "Nimzo-Indian Defense: Sämisch Variation, 6.e3" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3 O-O 6.e3
+E29 "Nimzo-Indian Defense: Sämisch Variation, Main Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.a3 Bxc3+ 5.bxc3 O-O 6.e3 c5 7.Bd3 Nc6
+E30 "Nimzo-Indian Defense: Leningrad Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Bg5
+E31 "Nimzo-Indian Defense: Leningrad Variation, Main Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Bg5 h6 5.Bh4 c5 6.d5 d6
+E32 "Nimzo-Indian Defense: Classical Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2
+E32 "Nimzo-Indian Defense: Classical Variation, 4...O-O" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 O-O
+E33 "Nimzo-Indian Defense: Classical Variation, Milner-Barry Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 Nc6
+E34 "Nimzo-Indian Defense: Classical Variation, Noa Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 d5
+E35 "Nimzo-Indian Defense: Classical Variation, Noa Variation, 5.cxd5 exd5" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 d5 5.cxd5 exd5
+E36 "Nimzo-Indian Defense: Classical Variation, Noa Variation, 5.a3" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 d5 5.a3
+E37 "Nimzo-Indian Defense: Classical Variation, Noa Variation, Main Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 d5 5.a3 Bxc3+ 6.Qxc3 Ne4 7.Qc2
+E38 "Nimzo-Indian Defense: Classical Variation, Berlin Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 c5
+E39 "Nimzo-Indian Defense: Classical Variation, Berlin Variation, Pirc Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.Qc2 c5 5.dxc5 O-O
+E40 "Nimzo-Indian Defense: Normal Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3
+E41 "Nimzo-Indian Defense: Hübner Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 c5
+E41 "Nimzo-Indian Defense: Hübner Variation, Main Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 c5 5.Bd3 Nc6 6.Nf3 Bxc3+ 7.bxc3 d6
+E42 "Nimzo-Indian Defense: Hübner Variation, Rubinstein Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 c5 5.Ne2
+E43 "Nimzo-Indian Defense: St. Petersburg Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 b6
+E44 "Nimzo-Indian Defense: Fischer Variation, 5.Ne2" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 b6 5.Ne2
+E45 "Nimzo-Indian Defense: St. Petersburg Variation, Bronstein Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 b6 5.Ne2 Ba6
+E46 "Nimzo-Indian Defense: Normal Variation, 4...O-O" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O
+E46 "Nimzo-Indian Defense: Reshevsky Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Ne2
+E47 "Nimzo-Indian Defense: Normal Variation, Bishop Attack" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Bd3
+E48 "Nimzo-Indian Defense: Normal Variation, Bishop Attack, Classical Defense" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Bd3 d5
+E49 "Nimzo-Indian Defense: Normal Variation, Botvinnik System" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Bd3 d5 6.a3 Bxc3+ 7.bxc3
+E50 "Nimzo-Indian Defense: Normal Variation, Hübner Deferred" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3
+E51 "Nimzo-Indian Defense: Normal Variation, Ragozin Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5
+E52 "Nimzo-Indian Defense: Normal Variation, Schlechter Defense" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 b6
+E53 "Nimzo-Indian Defense: Normal Variation, Gligoric System" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5
+E54 "Nimzo-Indian Defense: Normal Variation, Gligoric System, Exchange at c4" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O dxc4 8.Bxc4
+E55 "Nimzo-Indian Defense: Normal Variation, Gligoric System, Bronstein Variation" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O dxc4 8.Bxc4 Nbd7
+E56 "Nimzo-Indian Defense: Normal Variation, Gligoric System, 7...Nc6" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O Nc6
+E57 "Nimzo-Indian Defense: Normal Variation, Gligoric System, 8...dxc4 and 9...cxd4" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O Nc6 8.a3 dxc4 9.Bxc4 cxd4
+E58 "Nimzo-Indian Defense: Normal Variation, Gligoric System, 8...Bxc3" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O Nc6 8.a3 Bxc3 9.bxc3
+E59 "Nimzo-Indian Defense: Normal Variation, Gligoric System, Main Line" 1.d4 Nf6 2.c4 e6 3.Nc3 Bb4 4.e3 O-O 5.Nf3 d5 6.Bd3 c5 7.O-O Nc6 8.a3 Bxc3 9.bxc3 dxc4 10.Bxc4
+E60 "King's Indian Defense" 1.d4 Nf6 2.c4 g6
+E60 "King's Indian Defense: Normal Variation" 1.d4 Nf6 2.c4 g6 3.Nf3
+E61 "King's Indian Defense" 1.d4 Nf6 2.c4 g6 3.Nc3
+E61 "King's Indian Defense: Smyslov Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.Bg5
+E62 "King's Indian Defense: Fianchetto Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3
+E63 "King's Indian Defense: Fianchetto Variation, Panno Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 Nc6 7.O-O a6
+E64 "King's Indian Defense: Fianchetto Variation, Yugoslav System" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 c5
+E65 "King's Indian Defense: Fianchetto Variation, Yugoslav Variation, Rare Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 c5 7.O-O
+E66 "King's Indian Defense: Fianchetto Variation, Yugoslav Panno" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 c5 7.O-O Nc6 8.d5
+E67 "King's Indian Defense: Fianchetto Variation, Classical Fianchetto" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 Nbd7
+E68 "King's Indian Defense: Fianchetto Variation, Classical Variation, 8.e4" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 Nbd7 7.O-O e5 8.e4
+E69 "King's Indian Defense: Fianchetto Variation, Classical Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.Nf3 d6 5.g3 O-O 6.Bg2 Nbd7 7.O-O e5 8.e4 c6 9.h3
+E70 "King's Indian Defense: Normal Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4
+E70 "King's Indian Defense: Kramer Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nge2
+E71 "King's Indian Defense: Makogonov Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.h3
+E72 "King's Indian Defense: Normal Variation, Deferred Fianchetto" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.g3
+E73 "King's Indian Defense: Normal Variation, 5.Be2" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Be2
+E73 "King's Indian Defense: Averbakh Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Be2 O-O 6.Bg5
+E74 "King's Indian Defense: Averbakh Variation, Benoni Defense" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Be2 O-O 6.Bg5 c5
+E75 "King's Indian Defense: Averbakh Variation, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Be2 O-O 6.Bg5 c5 7.d5 e6
+E76 "King's Indian Defense: Four Pawns Attack" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f4
+E77 "King's Indian Defense: Four Pawns Attack, 6.Be2" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f4 O-O 6.Be2
+E78 "King's Indian Defense: Four Pawns Attack, Fluid Attack" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f4 O-O 6.Be2 c5 7.Nf3
+E79 "King's Indian Defense: Four Pawns Attack, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f4 O-O 6.Be2 c5 7.Nf3 cxd4 8.Nxd4 Nc6 9.Be3
+E80 "King's Indian Defense: Sämisch Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3
+E81 "King's Indian Defense: Sämisch Variation, Normal Defense" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O
+E82 "King's Indian Defense: Sämisch Variation, Double Fianchetto" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 b6
+E83 "King's Indian Defense: Sämisch Variation, Ruban Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 Nc6
+E84 "King's Indian Defense: Sämisch Variation, Panno Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 Nc6 7.Nge2 a6 8.Qd2 Rb8
+E85 "King's Indian Defense: Sämisch Variation, Orthodox Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 e5
+E86 "King's Indian Defense: Sämisch Variation, Orthodox Variation, 7.Nge2 c6" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 e5 7.Nge2 c6
+E87 "King's Indian Defense: Sämisch Variation, Closed Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 e5 7.d5
+E88 "King's Indian Defense: Sämisch Variation, Closed Variation, 7...c6" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 e5 7.d5 c6
+E89 "King's Indian Defense: Sämisch Variation, Closed Variation, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.f3 O-O 6.Be3 e5 7.d5 c6 8.Nge2 cxd5
+E90 "King's Indian Defense: Normal Variation, 5.Nf3" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3
+E91 "King's Indian Defense: Normal Variation, 6.Be2" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2
+E92 "King's Indian Defense: Orthodox Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5
+E92 "King's Indian Defense: Exchange Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.dxe5 dxe5 8.Qxd8 Rxd8
+E92 "King's Indian Defense: Petrosian Variation" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.d5
+E93 "King's Indian Defense: Petrosian Variation, Keres Defense" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.d5 Nbd7
+E94 "King's Indian Defense: Orthodox Variation, 7.O-O" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O
+E95 "King's Indian Defense: Orthodox Variation, 7...Nbd7, 8.Re1" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nbd7 8.Re1
+E96 "King's Indian Defense: Orthodox Variation, 7...Nbd7, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nbd7 8.Re1 c6 9.Bf1 a5
+E97 "King's Indian Defense: Orthodox Variation, Aronin-Taimanov Defense" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nc6
+E97 "King's Indian Defense: Orthodox Variation, Bayonet Attack" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nc6 8.d5 Ne7 9.b4
+E98 "King's Indian Defense: Orthodox Variation, Classical System" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nc6 8.d5 Ne7 9.Ne1
+E99 "King's Indian Defense: Orthodox Variation, Classical System, Main Line" 1.d4 Nf6 2.c4 g6 3.Nc3 Bg7 4.e4 d6 5.Nf3 O-O 6.Be2 e5 7.O-O Nc6 8.d5 Ne7 9.Ne1 Nd7 10.f3 f5
+`
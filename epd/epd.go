@@ -0,0 +1,300 @@
+package epd
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type errno int
+
+const (
+	InvalidPosition = errno(1 + iota)
+	IllegalMove
+	InvalidOperand
+	UnterminatedString
+)
+
+var errmap = map[errno]string{
+	InvalidPosition: "Invalid position",
+	IllegalMove: "Illegal move",
+	InvalidOperand: "Invalid operand",
+	UnterminatedString: "Unterminated string",
+}
+
+func (e errno) Error() string {
+	if msg, ok := errmap[e]; ok {
+		return msg
+	}
+	return "Unknown error"
+}
+
+// An EPD is a position with operations. The common opcodes are read
+// into fields, with moves resolved in the position, and any others
+// are kept as they were.
+type EPD struct {
+	Game *chess.Game            // position, with hmvc and fmvn applied
+	ID string                   // id
+	BestMoves []*chess.Move     // bm
+	AvoidMoves []*chess.Move    // am
+	CE *int                     // ce, centipawns for the side to move
+	ACD int                     // acd, analysis depth
+	PV []*chess.Move            // pv, played from the position
+	Comments [10]string         // c0 to c9
+	Ops map[string][]string     // other opcodes and their operands
+}
+
+// the four position fields, then the operations
+var reEPD = regexp.MustCompile("^\\s*(\\S+)\\s+(\\S+)\\s+(\\S+)\\s+(\\S+)\\s*(.*)$")
+var reComment = regexp.MustCompile("^c(\\d)$")
+
+func Parse(line string) (*EPD, error) {
+	return ParseVariant(line, nil)
+}
+
+func ParseVariant(line string, v chess.Variant) (*EPD, error) {
+	m := reEPD.FindStringSubmatch(line)
+
+	if m == nil {
+		return nil, InvalidPosition
+	}
+
+	// the move counters come from hmvc and fmvn, if given
+	g := fen.ParseVariant(fmt.Sprintf("%s %s %s %s 0 1", m[1], m[2], m[3], m[4]), v)
+
+	if g == nil {
+		return nil, InvalidPosition
+	}
+
+	ops, err := operations(m[5])
+
+	if err != nil {
+		return nil, err
+	}
+
+	e := &EPD{ Game: g, Ops: make(map[string][]string) }
+
+	for _, op := range ops {
+		if err := e.apply(op[0], op[1:]); err != nil {
+			return nil, err
+		}
+	}
+
+	return e, nil
+}
+
+// operations splits the text after the position into operations,
+// each an opcode and its operands, ended by semicolons. Quoted
+// operands may contain spaces and semicolons.
+func operations(s string) ([][]string, error) {
+	var ops [][]string
+	var op []string
+
+	for {
+		// skip whitespace
+		for len(s) > 0 && (s[0] == ' ' || s[0] == '\t') {
+			s = s[1:]
+		}
+
+		if len(s) == 0 {
+			break
+		}
+
+		switch s[0] {
+			case ';':
+				if len(op) > 0 {
+					ops = append(ops, op)
+				}
+
+				op = nil
+				s = s[1:]
+				break
+			case '"':
+				end := 1
+
+				for end < len(s) && s[end] != '"' {
+					end++
+				}
+
+				if end == len(s) {
+					return nil, UnterminatedString
+				}
+
+				op = append(op, s[1:end])
+				s = s[end + 1:]
+				break
+			default:
+				end := 0
+
+				for end < len(s) && s[end] != ' ' && s[end] != '\t' && s[end] != ';' {
+					end++
+				}
+
+				op = append(op, s[:end])
+				s = s[end:]
+				break
+		}
+	}
+
+	// the last semicolon is often left off
+	if len(op) > 0 {
+		ops = append(ops, op)
+	}
+
+	return ops, nil
+}
+
+func (e *EPD) apply(opcode string, operands []string) error {
+	var err error
+
+	// opcodes taking a single operand
+	single := func(f func(s string) error) error {
+		if len(operands) != 1 {
+			return InvalidOperand
+		}
+		return f(operands[0])
+	}
+
+	number := func(n *int) func(s string) error {
+		return func(s string) error {
+			var err error
+
+			if *n, err = strconv.Atoi(s); err != nil {
+				return InvalidOperand
+			}
+
+			return nil
+		}
+	}
+
+	if c := reComment.FindStringSubmatch(opcode); c != nil {
+		i, _ := strconv.Atoi(c[1])
+
+		return single(func(s string) error {
+			e.Comments[i] = s
+			return nil
+		})
+	}
+
+	switch opcode {
+		case "id":
+			err = single(func(s string) error {
+				e.ID = s
+				return nil
+			})
+			break
+		case "bm":
+			e.BestMoves, err = e.moves(operands)
+			break
+		case "am":
+			e.AvoidMoves, err = e.moves(operands)
+			break
+		case "ce":
+			e.CE = new(int)
+			err = single(number(e.CE))
+			break
+		case "acd":
+			err = single(number(&e.ACD))
+			break
+		case "pv":
+			e.PV, err = e.line(operands)
+			break
+		case "hmvc":
+			err = single(number(&e.Game.HalfMove))
+			break
+		case "fmvn":
+			err = single(number(&e.Game.Move))
+			break
+		default:
+			e.Ops[opcode] = operands
+			break
+	}
+
+	return err
+}
+
+// moves resolves SAN moves in the position.
+func (e *EPD) moves(operands []string) ([]*chess.Move, error) {
+	if len(operands) == 0 {
+		return nil, InvalidOperand
+	}
+
+	moves := make([]*chess.Move, 0, len(operands))
+
+	for _, s := range operands {
+		move := e.Game.ParseMove(s)
+
+		if move == nil {
+			return nil, IllegalMove
+		}
+
+		moves = append(moves, move)
+	}
+
+	return moves, nil
+}
+
+// line resolves SAN moves played one after another.
+func (e *EPD) line(operands []string) ([]*chess.Move, error) {
+	g := *e.Game
+	moves := make([]*chess.Move, 0, len(operands))
+
+	for _, s := range operands {
+		move := g.ParseMove(s)
+
+		if move == nil {
+			return nil, IllegalMove
+		}
+
+		moves = append(moves, move)
+		g.PerformMove(move)
+	}
+
+	return moves, nil
+}
+
+// Read parses a position from each line of a reader, skipping blank
+// lines.
+func Read(r io.Reader) ([]*EPD, error) {
+	scanner := bufio.NewScanner(r)
+	epds := make([]*EPD, 0, 16)
+
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		e, err := Parse(line)
+
+		if err != nil {
+			return epds, fmt.Errorf("line %d: %s", n, err)
+		}
+
+		epds = append(epds, e)
+	}
+
+	return epds, scanner.Err()
+}
+
+func ParseFile(filename string) ([]*EPD, error) {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return Read(f)
+}
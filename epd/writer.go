@@ -0,0 +1,124 @@
+package epd
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Write writes positions as EPD, one per line.
+func Write(w io.Writer, epds ...*EPD) error {
+	for _, e := range epds {
+		if _, err := io.WriteString(w, e.String() + "\n"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String formats the position and its operations as a line of EPD.
+// Moves are written in SAN, and the move counters only when they
+// aren't the defaults.
+func (e *EPD) String() string {
+	fields := strings.Split(fen.Format(e.Game), " ")[:4]
+
+	add := func(opcode string, operands ...string) {
+		fields = append(fields, strings.Join(append([]string{ opcode }, operands...), " ") + ";")
+	}
+
+	if len(e.BestMoves) > 0 {
+		add("bm", moves(e.Game, e.BestMoves)...)
+	}
+
+	if len(e.AvoidMoves) > 0 {
+		add("am", moves(e.Game, e.AvoidMoves)...)
+	}
+
+	if e.ID != "" {
+		add("id", "\"" + e.ID + "\"")
+	}
+
+	if e.CE != nil {
+		add("ce", strconv.Itoa(*e.CE))
+	}
+
+	if e.ACD > 0 {
+		add("acd", strconv.Itoa(e.ACD))
+	}
+
+	if len(e.PV) > 0 {
+		add("pv", line(*e.Game, e.PV)...)
+	}
+
+	for i, c := range e.Comments {
+		if c != "" {
+			add(fmt.Sprintf("c%d", i), "\"" + c + "\"")
+		}
+	}
+
+	// the rest sorted by opcode
+	opcodes := make([]string, 0, len(e.Ops))
+
+	for opcode := range e.Ops {
+		opcodes = append(opcodes, opcode)
+	}
+
+	sort.Strings(opcodes)
+
+	for _, opcode := range opcodes {
+		operands := make([]string, len(e.Ops[opcode]))
+
+		for i, s := range e.Ops[opcode] {
+			operands[i] = quote(s)
+		}
+
+		add(opcode, operands...)
+	}
+
+	if e.Game.HalfMove != 0 {
+		add("hmvc", strconv.Itoa(e.Game.HalfMove))
+	}
+
+	if e.Game.Move != 1 {
+		add("fmvn", strconv.Itoa(e.Game.Move))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func moves(g *chess.Game, moves []*chess.Move) []string {
+	sans := make([]string, len(moves))
+
+	for i, move := range moves {
+		sans[i] = g.SAN(move)
+	}
+
+	return sans
+}
+
+func line(g chess.Game, moves []*chess.Move) []string {
+	sans := make([]string, len(moves))
+
+	for i, move := range moves {
+		sans[i] = g.SAN(move)
+		g.PerformMove(move)
+	}
+
+	return sans
+}
+
+// quote an operand when it can't be written bare
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t;\"") {
+		return "\"" + s + "\""
+	}
+	return s
+}
@@ -1,6 +1,7 @@
 package fen
 
 import (
+	"fmt"
 	"strings"
 	"strconv"
 )
@@ -12,32 +13,64 @@ const (
 )
 
 var PieceMap = map[rune]chess.Piece{
-	'P': chess.Piece{Color: chess.White, chess.Kind: chess.Pawn},
-	'B': chess.Piece{Color: chess.White, chess.Kind: chess.Bishop},
-	'N': chess.Piece{Color: chess.White, chess.Kind: chess.Knight},
-	'R': chess.Piece{Color: chess.White, chess.Kind: chess.Rook},
-	'Q': chess.Piece{Color: chess.White, chess.Kind: chess.Queen},
-	'K': chess.Piece{Color: chess.White, chess.Kind: chess.King},
-	'p': chess.Piece{Color: chess.Black, chess.Kind: chess.Pawn},
-	'b': chess.Piece{Color: chess.Black, chess.Kind: chess.Bishop},
-	'n': chess.Piece{Color: chess.Black, chess.Kind: chess.Knight},
-	'r': chess.Piece{Color: chess.Black, chess.Kind: chess.Rook},
-	'q': chess.Piece{Color: chess.Black, chess.Kind: chess.Queen},
-	'k': chess.Piece{Color: chess.Black, chess.Kind: chess.King},
+	'P': chess.Piece{Color: chess.White, Kind: chess.Pawn},
+	'B': chess.Piece{Color: chess.White, Kind: chess.Bishop},
+	'N': chess.Piece{Color: chess.White, Kind: chess.Knight},
+	'R': chess.Piece{Color: chess.White, Kind: chess.Rook},
+	'Q': chess.Piece{Color: chess.White, Kind: chess.Queen},
+	'K': chess.Piece{Color: chess.White, Kind: chess.King},
+	'p': chess.Piece{Color: chess.Black, Kind: chess.Pawn},
+	'b': chess.Piece{Color: chess.Black, Kind: chess.Bishop},
+	'n': chess.Piece{Color: chess.Black, Kind: chess.Knight},
+	'r': chess.Piece{Color: chess.Black, Kind: chess.Rook},
+	'q': chess.Piece{Color: chess.Black, Kind: chess.Queen},
+	'k': chess.Piece{Color: chess.Black, Kind: chess.King},
 }
 
 func Parse(fen string) *chess.Game {
+	return ParseVariant(fen, nil)
+}
+
+func ParseVariant(fen string, v chess.Variant) *chess.Game {
 	g := new(chess.Game)
 
 	// divide the FEN into its components
 	sections := strings.Split(fen, " ")
 
+	// three-check positions may have a check counter
+	if len(sections) == 7 {
+		switch {
+			case strings.HasPrefix(sections[6], "+"):
+				if !setChecks(g, sections[6][1:], false) { return nil }
+				sections = sections[:6]
+				break
+			case strings.Contains(sections[4], "+"):
+				if !setChecks(g, sections[4], true) { return nil }
+				sections = append(sections[:4], sections[5:]...)
+				break
+		}
+
+		if v == nil {
+			v = chess.ThreeCheck{}
+		}
+	}
+
 	if len(sections) != 6 {
 		return nil
 	}
 
+	if v == nil {
+		v = chess.Standard{}
+	}
+
+	// kings are located while setting the board
+	g.King[chess.White] = -1
+	g.King[chess.Black] = -1
+	g.Variant = v
+
 	// initialize each part of the game
 	if !setBoard(g, sections[0]) { return nil }
+	g.SyncBitboards()
 	if !setTurn(g, sections[1]) { return nil }
 	if !setCastle(g, sections[2]) { return nil }
 	if !setEnPassant(g, sections[3]) { return nil }
@@ -140,7 +173,7 @@ func setEnPassant(g *chess.Game, ep string) bool {
 		file := int(ep[0]) - int('a')
 		rank := int(ep[1]) - int('1')
 
-		if file < 0 || file > 7 || (rank != 1 && rank != 6) {
+		if file < 0 || file > 7 || (rank != 2 && rank != 5) {
 			return false
 		}
 
@@ -169,3 +202,113 @@ func setMove(g *chess.Game, move string) bool {
 
 	return err == nil
 }
+
+func setChecks(g *chess.Game, checks string, remaining bool) bool {
+	counts := strings.Split(checks, "+")
+
+	if len(counts) != 2 {
+		return false
+	}
+
+	for i, c := range [2]chess.Color{ chess.White, chess.Black } {
+		n, err := strconv.Atoi(counts[i])
+
+		if err != nil || n < 0 || n > 3 {
+			return false
+		}
+
+		// lichess counts the checks remaining instead of given
+		if remaining {
+			n = 3 - n
+		}
+
+		g.Checks[c] = n
+	}
+
+	return true
+}
+
+func Format(g *chess.Game) string {
+	fields := []string{
+		formatBoard(g),
+		formatTurn(g),
+		formatCastle(g),
+		formatEnPassant(g),
+		strconv.Itoa(g.HalfMove),
+		strconv.Itoa(g.Move),
+	}
+
+	// three-check games include the checks given
+	if _, ok := g.Variant.(chess.ThreeCheck); ok {
+		fields = append(fields, fmt.Sprintf("+%d+%d", g.Checks[chess.White], g.Checks[chess.Black]))
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func formatBoard(g *chess.Game) string {
+	ranks := make([]string, 0, 8)
+
+	for rank := 7; rank >= 0; rank-- {
+		s := ""
+		empty := 0
+
+		for file := 0; file < 8; file++ {
+			p := g.Position.Piece(chess.Tile(rank, file))
+
+			if p == nil {
+				empty++
+				continue
+			}
+
+			if empty > 0 {
+				s += strconv.Itoa(empty)
+			}
+
+			s += string(p.Rune())
+			empty = 0
+		}
+
+		if empty > 0 {
+			s += strconv.Itoa(empty)
+		}
+
+		ranks = append(ranks, s)
+	}
+
+	return strings.Join(ranks, "/")
+}
+
+func formatTurn(g *chess.Game) string {
+	if g.Turn == chess.White {
+		return "w"
+	}
+	return "b"
+}
+
+func formatCastle(g *chess.Game) string {
+	s := ""
+
+	for _, c := range [2]chess.Color{ chess.White, chess.Black } {
+		if g.Castles & (chess.Kingside << uint(c << 2)) != 0 {
+			s += string(chess.PieceRunes[c][chess.King])
+		}
+
+		if g.Castles & (chess.Queenside << uint(c << 2)) != 0 {
+			s += string(chess.PieceRunes[c][chess.Queen])
+		}
+	}
+
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+func formatEnPassant(g *chess.Game) string {
+	if g.EnPassant < 0 {
+		return "-"
+	}
+	return chess.TileNotation(g.EnPassant)
+}
@@ -0,0 +1,567 @@
+package match
+
+import (
+	"../chess"
+	"../clock"
+	"../pgn"
+	"../uci"
+)
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errTimeout = errors.New("out of time")
+
+// mates are scored past any adjudication threshold
+const mateScore = 100000
+
+var colors = [2]string{ "White", "Black" }
+
+var results = map[chess.Outcome]int{
+	chess.InProgress: pgn.InProgress,
+	chess.Draw: pgn.Draw,
+	chess.WhiteWins: pgn.WhiteWins,
+	chess.BlackWins: pgn.BlackWins,
+}
+
+// A worker plays games one after another with its own engine
+// processes. An engine that crashes or runs out of time is replaced
+// before the next game.
+type worker struct {
+	m *Match
+	clients [2]*uci.Client
+}
+
+// a game being played, refereed by the worker's own position
+type game struct {
+	g chess.Game
+	fen string                  // starting position, empty for the standard start
+	moves []string              // moves since the start in UCI notation
+	hashes []uint64             // every position reached
+	players [2]int              // engine playing each color
+	clocks [2]time.Duration     // time left for each color
+	played [2]int               // moves made by each color
+	win, draw int               // plies in a row meeting the adjudication scores
+	leader chess.Color          // who was ahead for the win adjudication
+	out *pgn.PGN
+}
+
+func (w *worker) play(i int) (*Game, error) {
+	m := w.m
+	opening := m.opening(i)
+
+	// the first game of each pair has the first engine as white
+	white := i % 2
+
+	gm := &game{
+		g: *opening.Game,
+		fen: opening.fen(),
+		players: [2]int{ white, 1 - white },
+		clocks: [2]time.Duration{ m.TimeControl.Time, m.TimeControl.Time },
+	}
+
+	gm.hashes = append(gm.hashes, gm.g.Hash())
+
+	for _, e := range gm.players {
+		if err := w.newGame(e, &gm.g); err != nil {
+			return nil, err
+		}
+	}
+
+	gm.out = &pgn.PGN{ Tags: w.tags(i, opening, white) }
+
+	// the opening moves aren't played by the engines
+	for _, move := range opening.Moves {
+		gm.perform(move, &pgn.Move{ Move: move })
+	}
+
+	outcome, reason := chess.InProgress, ""
+
+	for outcome == chess.InProgress {
+		if outcome, reason = gm.over(&m.Adjudication); outcome == chess.InProgress {
+			outcome, reason = w.turn(gm)
+		}
+	}
+
+	gm.finish(outcome, reason)
+
+	return &Game{
+		PGN: gm.out,
+		Number: i + 1,
+		Pair: i / 2 + 1,
+		White: white,
+		Outcome: outcome,
+		Reason: reason,
+	}, nil
+}
+
+// turn has the engine to move search and plays its move. The game
+// only ends here when the engine fails to move.
+func (w *worker) turn(gm *game) (chess.Outcome, string) {
+	tc := &w.m.TimeControl
+	c := gm.g.Turn
+	e := gm.players[c]
+	lost := chess.Win(c.Opponent())
+
+	a, elapsed, err := w.think(e, gm)
+
+	switch {
+		case err == errTimeout:
+			return flag(&gm.g, c)
+		case err != nil:
+			w.stop(e)
+			return lost, colors[c] + "'s engine disconnects"
+		case gm.late(tc, elapsed):
+			return flag(&gm.g, c)
+	}
+
+	move := gm.g.ParseUCI(a.BestMove)
+
+	if move == nil {
+		if a.BestMove == "" {
+			a.BestMove = "(none)"
+		}
+		return lost, fmt.Sprintf("%s makes an illegal move: %s", colors[c], a.BestMove)
+	}
+
+	gm.clock(tc, elapsed)
+
+	m := &pgn.Move{ Move: move }
+	score, ok := 0, len(a.Lines) > 0
+
+	if tc.Time > 0 {
+		left := gm.clocks[c]
+		m.Clock = &left
+	}
+
+	if ok {
+		info := a.Lines[0]
+
+		m.Eval, score = eval(info, c)
+		m.Comment = fmt.Sprintf("depth %d, %s", info.Depth, elapsed.Round(time.Millisecond))
+	} else {
+		m.Comment = elapsed.Round(time.Millisecond).String()
+	}
+
+	gm.perform(move, m)
+	gm.score(&w.m.Adjudication, score, ok)
+
+	return chess.InProgress, ""
+}
+
+// flag is the outcome when a player runs out of time, a draw if the
+// opponent couldn't mate anyway.
+func flag(g *chess.Game, c chess.Color) (chess.Outcome, string) {
+	if outcome := clock.Flag(g, c); outcome == chess.Draw {
+		return outcome, fmt.Sprintf("%s runs out of time but %s can't mate", colors[c], colors[c.Opponent()])
+	}
+	return chess.Win(c.Opponent()), colors[c] + " loses on time"
+}
+
+// think sends the position to an engine and waits for its move, but
+// no longer than the player has.
+func (w *worker) think(e int, gm *game) (*uci.Analysis, time.Duration, error) {
+	client := w.clients[e]
+
+	if err := client.Position(gm.fen, gm.moves); err != nil {
+		return nil, 0, err
+	}
+
+	type reply struct {
+		a *uci.Analysis
+		err error
+	}
+
+	replies := make(chan reply, 1)
+	limits := gm.limits(&w.m.TimeControl)
+	start := time.Now()
+
+	go func() {
+		a, err := client.Go(limits)
+		replies <- reply{ a, err }
+	}()
+
+	var timeout <-chan time.Time
+
+	if limit := gm.limit(&w.m.TimeControl); limit > 0 {
+		timer := time.NewTimer(limit)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+		case r := <-replies:
+			return r.a, time.Since(start), r.err
+		case <-timeout:
+			// the engine may never answer, so it's replaced
+			w.stop(e)
+			return nil, time.Since(start), errTimeout
+	}
+}
+
+// limits sent with go for the player to move
+func (gm *game) limits(tc *TimeControl) uci.Go {
+	limits := uci.Go{ MoveTime: tc.MoveTime, Depth: tc.Depth, Nodes: tc.Nodes }
+
+	if tc.Time > 0 {
+		// a clock run down within the margin is still sent as some time
+		clock := func(c chess.Color) time.Duration {
+			if gm.clocks[c] < time.Millisecond {
+				return time.Millisecond
+			}
+			return gm.clocks[c]
+		}
+
+		limits.WTime, limits.BTime = clock(chess.White), clock(chess.Black)
+		limits.WInc, limits.BInc = tc.Inc, tc.Inc
+
+		if tc.Moves > 0 {
+			limits.MovesToGo = tc.Moves - gm.played[gm.g.Turn] % tc.Moves
+		}
+	}
+
+	return limits
+}
+
+// limit is how long the player to move has, including the margin, or
+// 0 if there is no limit.
+func (gm *game) limit(tc *TimeControl) time.Duration {
+	switch {
+		case tc.Time > 0:
+			return gm.clocks[gm.g.Turn] + tc.Margin
+		case tc.MoveTime > 0:
+			return tc.MoveTime + tc.Margin
+	}
+
+	return 0
+}
+
+func (gm *game) late(tc *TimeControl, elapsed time.Duration) bool {
+	limit := gm.limit(tc)
+
+	return limit > 0 && elapsed > limit
+}
+
+// clock takes the time used for a move from the player's clock, and
+// adds the increment and the time for a new period.
+func (gm *game) clock(tc *TimeControl, elapsed time.Duration) {
+	c := gm.g.Turn
+
+	if tc.Time == 0 {
+		return
+	}
+
+	gm.played[c]++
+	gm.clocks[c] += tc.Inc - elapsed
+
+	if tc.Moves > 0 && gm.played[c] % tc.Moves == 0 {
+		gm.clocks[c] += tc.Time
+	}
+}
+
+// perform plays a move in the arbiter's position and records it.
+func (gm *game) perform(move *chess.Move, m *pgn.Move) {
+	// white starts a new pair of moves
+	if gm.g.Turn == chess.White || len(gm.out.Moves) == 0 {
+		gm.out.Moves = append(gm.out.Moves, [2]*pgn.Move{})
+	}
+
+	gm.out.Moves[len(gm.out.Moves) - 1][gm.g.Turn] = m
+	gm.moves = append(gm.moves, move.UCI())
+
+	gm.g.PerformMove(move)
+	gm.hashes = append(gm.hashes, gm.g.Hash())
+}
+
+// eval is an engine's score for the player to move, from white's side,
+// as a PGN evaluation and for adjudication.
+func eval(info uci.Info, c chess.Color) (*pgn.Eval, int) {
+	e := &pgn.Eval{ Centipawns: info.Score, Mate: info.Mate }
+	score := info.Score
+
+	switch {
+		case info.Mate > 0:
+			score = mateScore
+			break
+		case info.Mate < 0:
+			score = -mateScore
+			break
+	}
+
+	if c == chess.Black {
+		e.Centipawns, e.Mate, score = -e.Centipawns, -e.Mate, -score
+	}
+
+	return e, score
+}
+
+// score counts the plies in a row that meet the adjudication scores.
+// A ply without a score breaks both.
+func (gm *game) score(adj *Adjudication, score int, ok bool) {
+	leader, margin := chess.White, score
+
+	if score < 0 {
+		leader, margin = chess.Black, -score
+	}
+
+	switch {
+		case ok == false || margin < adj.WinScore:
+			gm.win = 0
+			break
+		case gm.win > 0 && leader != gm.leader:
+			gm.win = 1
+			break
+		default:
+			gm.win++
+			break
+	}
+
+	gm.leader = leader
+
+	if ok && gm.g.Move >= adj.DrawStart && margin <= adj.DrawScore {
+		gm.draw++
+	} else {
+		gm.draw = 0
+	}
+}
+
+// over decides whether the game has ended, by the rules or by
+// adjudication.
+func (gm *game) over(adj *Adjudication) (chess.Outcome, string) {
+	g := &gm.g
+
+	if outcome := g.Outcome(); outcome != chess.InProgress {
+		return outcome, gm.ending(outcome)
+	}
+
+	switch {
+		case g.HalfMove >= 100:
+			return chess.Draw, "Draw by fifty moves rule"
+		case gm.repetitions() >= 3:
+			return chess.Draw, "Draw by 3-fold repetition"
+		case g.InsufficientMaterial():
+			return chess.Draw, "Draw by insufficient mating material"
+	}
+
+	if adj.Tablebase != nil {
+		if outcome, ok := adj.Tablebase.Probe(g); ok {
+			return outcome, "Tablebase adjudication"
+		}
+	}
+
+	switch {
+		case adj.WinMoves > 0 && adj.WinScore > 0 && gm.win >= adj.WinMoves * 2:
+			return chess.Win(gm.leader), colors[gm.leader] + " wins by adjudication"
+		case adj.DrawMoves > 0 && gm.draw >= adj.DrawMoves * 2:
+			return chess.Draw, "Draw by adjudication"
+		case adj.MaxMoves > 0 && len(gm.moves) >= adj.MaxMoves * 2:
+			return chess.Draw, "Draw by adjudication: move limit"
+	}
+
+	return chess.InProgress, ""
+}
+
+// ending describes how a game was won or drawn on the board.
+func (gm *game) ending(outcome chess.Outcome) string {
+	g := &gm.g
+	stuck := len(g.CollectMoves()) == 0
+
+	switch {
+		case outcome == chess.Draw && stuck:
+			return "Draw by stalemate"
+		case outcome == chess.Draw:
+			return "Draw"
+		case outcome == chess.Win(g.Turn.Opponent()) && stuck && g.Checkers() != 0:
+			return colors[g.Turn.Opponent()] + " mates"
+		case outcome == chess.WhiteWins:
+			return "White wins"
+	}
+
+	return "Black wins"
+}
+
+// repetitions of the current position, only since the last capture or
+// pawn move since nothing before can repeat.
+func (gm *game) repetitions() int {
+	n := 0
+	last := len(gm.hashes) - 1
+
+	for i := last; i >= 0 && i >= last - gm.g.HalfMove; i-- {
+		if gm.hashes[i] == gm.hashes[last] {
+			n++
+		}
+	}
+
+	return n
+}
+
+// finish sets the result, with how the game ended as the comment of
+// the last move.
+func (gm *game) finish(outcome chess.Outcome, reason string) {
+	gm.out.Result = results[outcome]
+	gm.out.Tags["Termination"] = termination(reason)
+
+	if n := len(gm.out.Moves); n > 0 {
+		last := gm.out.Moves[n - 1][gm.g.Turn.Opponent()]
+
+		if last != nil && last.Comment != "" {
+			last.Comment += ", " + reason
+			return
+		}
+
+		if last != nil {
+			last.Comment = reason
+			return
+		}
+	}
+
+	gm.out.Comment = reason
+}
+
+func termination(reason string) string {
+	switch {
+		case strings.Contains(reason, "on time"), strings.Contains(reason, "out of time"):
+			return "time forfeit"
+		case strings.Contains(reason, "illegal move"):
+			return "illegal move"
+		case strings.Contains(reason, "disconnects"):
+			return "abandoned"
+		case strings.Contains(reason, "adjudication"):
+			return "adjudication"
+	}
+
+	return "normal"
+}
+
+func (w *worker) tags(i int, o *Opening, white int) map[string]string {
+	tags := map[string]string{
+		"Date": time.Now().Format("2006.01.02"),
+		"Round": strconv.Itoa(i + 1),
+		"White": w.name(white),
+		"Black": w.name(1 - white),
+		"TimeControl": w.m.TimeControl.String(),
+	}
+
+	if w.m.Event != "" {
+		tags["Event"] = w.m.Event
+	}
+
+	if w.m.Site != "" {
+		tags["Site"] = w.m.Site
+	}
+
+	if s := o.fen(); s != "" {
+		tags["FEN"] = s
+		tags["SetUp"] = "1"
+	}
+
+	if o.Game.IsStandard() == false {
+		tags["Variant"] = o.Game.Variant.Name()
+	}
+
+	if o.Name != "" {
+		tags["Opening"] = o.Name
+	}
+
+	return tags
+}
+
+// name of an engine, as given or from its id
+func (w *worker) name(e int) string {
+	engine := &w.m.Engines[e]
+
+	switch {
+		case engine.Name != "":
+			return engine.Name
+		case w.clients[e] != nil && w.clients[e].Name != "":
+			return w.clients[e].Name
+	}
+
+	return filepath.Base(engine.Path)
+}
+
+// start runs an engine if it isn't running, and sets its options.
+func (w *worker) start(e int) error {
+	if w.clients[e] != nil {
+		return nil
+	}
+
+	engine := &w.m.Engines[e]
+	client, err := uci.Start(engine.Path, engine.Args...)
+
+	if err != nil {
+		return fmt.Errorf("%s: %s", engine.Path, err)
+	}
+
+	names := make([]string, 0, len(engine.Options))
+
+	for name := range engine.Options {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		client.SetOption(name, engine.Options[name])
+	}
+
+	if err = client.IsReady(); err != nil {
+		client.Close()
+		return fmt.Errorf("%s: %s", engine.Path, err)
+	}
+
+	w.clients[e] = client
+	return nil
+}
+
+// newGame readies an engine for a game, restarting it once if it
+// crashed since the last one.
+func (w *worker) newGame(e int, g *chess.Game) error {
+	var err error
+
+	for tries := 0; tries < 2; tries++ {
+		if err = w.start(e); err != nil {
+			return err
+		}
+
+		client := w.clients[e]
+
+		if g.IsStandard() == false {
+			client.SetOption("UCI_Variant", strings.ToLower(g.Variant.Name()))
+		}
+
+		if err = client.NewGame(); err == nil {
+			return nil
+		}
+
+		w.stop(e)
+	}
+
+	return fmt.Errorf("%s: %s", w.m.Engines[e].Path, err)
+}
+
+// stop quits an engine without waiting for it, to be started again
+// for the next game.
+func (w *worker) stop(e int) {
+	if client := w.clients[e]; client != nil {
+		go client.Close()
+	}
+
+	w.clients[e] = nil
+}
+
+func (w *worker) close() {
+	for _, client := range w.clients {
+		if client != nil {
+			client.Close()
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package match
+
+import (
+	"../chess"
+	"../pgn"
+)
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An Engine is how to run one of the players.
+type Engine struct {
+	Name string                   // for the PGN tags, the engine's id name if empty
+	Path string                   // executable
+	Args []string                 // command line arguments
+	Options map[string]string     // sent with setoption after starting
+}
+
+// A TimeControl is the limit on each move. With Time, each player has
+// a clock of that much for every Moves moves (or the whole game if 0),
+// with Inc added after each move. Otherwise MoveTime, Depth and Nodes
+// are sent with every go.
+type TimeControl struct {
+	Moves int
+	Time time.Duration
+	Inc time.Duration
+	MoveTime time.Duration
+	Depth int
+	Nodes int
+	Margin time.Duration          // allowed past the clock before losing on time
+}
+
+// Adjudication ends games early. Zero values are never used.
+type Adjudication struct {
+	// a win when both engines score the same side ahead by at least
+	// WinScore for WinMoves moves in a row
+	WinScore int
+	WinMoves int
+
+	// a draw from move DrawStart, when both engines score within
+	// DrawScore of 0 for DrawMoves moves in a row
+	DrawStart int
+	DrawScore int
+	DrawMoves int
+
+	// a draw once this many moves have been played
+	MaxMoves int
+
+	// the result of positions found in the tablebase
+	Tablebase Tablebase
+}
+
+// A Tablebase knows the result of some positions with perfect play.
+type Tablebase interface {
+	Probe(g *chess.Game) (chess.Outcome, bool)
+}
+
+// A Match plays two engines against each other. Each opening is played
+// twice, once with each engine as white, and several games are played
+// at a time, each by its own pair of engine processes.
+type Match struct {
+	Engines [2]Engine
+	TimeControl TimeControl
+	Adjudication Adjudication
+	Openings []*Opening           // played in order and repeated, start position if none
+	Games int                     // number of games, made even
+	Concurrency int               // games played at a time
+	Event string                  // PGN Event and Site tags
+	Site string
+}
+
+// A Game is a finished game of a match.
+type Game struct {
+	PGN *pgn.PGN
+	Number int                    // in the order of the schedule, from 1
+	Pair int                      // games with the same opening, from 1
+	White int                     // index of the engine playing white
+	Outcome chess.Outcome
+	Reason string                 // how the game ended, e.g. "White mates"
+}
+
+// Score is the results of the first engine against the second.
+type Score struct {
+	Wins, Draws, Losses int
+}
+
+// Run plays the match, calling report as each game finishes. Reports
+// are never made at the same time. An engine that can't be started
+// ends the match with an error, while one that crashes or plays an
+// illegal move only loses the game.
+func (m *Match) Run(report func(*Game)) (Score, error) {
+	var score Score
+	var lock sync.Mutex
+	var failure error
+
+	games := m.Games + m.Games % 2
+	concurrency := m.Concurrency
+
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	schedule := make(chan int, games)
+
+	for i := 0; i < games; i++ {
+		schedule <- i
+	}
+
+	close(schedule)
+
+	var wait sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wait.Add(1)
+
+		go func() {
+			defer wait.Done()
+
+			w := &worker{ m: m }
+			defer w.close()
+
+			for i := range schedule {
+				game, err := w.play(i)
+
+				lock.Lock()
+
+				if err != nil && failure == nil {
+					failure = err
+				}
+
+				if err == nil {
+					score.add(game)
+
+					if report != nil {
+						report(game)
+					}
+				}
+
+				// the games being played are finished, but no more start
+				stop := failure != nil
+
+				lock.Unlock()
+
+				if stop {
+					return
+				}
+			}
+		}()
+	}
+
+	wait.Wait()
+
+	return score, failure
+}
+
+// opening of the i-th game
+func (m *Match) opening(i int) *Opening {
+	if len(m.Openings) == 0 {
+		return StartPosition
+	}
+	return m.Openings[(i / 2) % len(m.Openings)]
+}
+
+func (s *Score) add(game *Game) {
+	switch {
+		case game.Outcome == chess.Draw:
+			s.Draws++
+			break
+		case game.Outcome == chess.Win(chess.White) && game.White == 0:
+			s.Wins++
+			break
+		case game.Outcome == chess.Win(chess.Black) && game.White == 1:
+			s.Wins++
+			break
+		default:
+			s.Losses++
+			break
+	}
+}
+
+func (s Score) Games() int {
+	return s.Wins + s.Draws + s.Losses
+}
+
+// Ratio is the points scored by the first engine out of 1.
+func (s Score) Ratio() float64 {
+	if s.Games() == 0 {
+		return 0
+	}
+	return (float64(s.Wins) + float64(s.Draws) / 2) / float64(s.Games())
+}
+
+func (s Score) String() string {
+	return fmt.Sprintf("%d - %d - %d [%.3f] %d", s.Wins, s.Losses, s.Draws, s.Ratio(), s.Games())
+}
+
+// ParseTimeControl reads a time control in seconds as moves/time+inc,
+// e.g. "40/60+0.6" or "10+0.1", with the moves and increment optional.
+func ParseTimeControl(s string) (TimeControl, error) {
+	var tc TimeControl
+	var err error
+
+	if i := strings.Index(s, "/"); i >= 0 {
+		if tc.Moves, err = strconv.Atoi(s[:i]); err != nil || tc.Moves < 1 {
+			return tc, fmt.Errorf("invalid time control %s", s)
+		}
+
+		s = s[i + 1:]
+	}
+
+	if i := strings.Index(s, "+"); i >= 0 {
+		if tc.Inc, err = seconds(s[i + 1:]); err != nil {
+			return tc, fmt.Errorf("invalid increment %s", s[i + 1:])
+		}
+
+		s = s[:i]
+	}
+
+	if tc.Time, err = seconds(s); err != nil || tc.Time <= 0 {
+		return tc, fmt.Errorf("invalid time %s", s)
+	}
+
+	return tc, nil
+}
+
+func seconds(s string) (time.Duration, error) {
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid seconds %s", s)
+	}
+
+	return time.Duration(f * float64(time.Second)), nil
+}
+
+// String is the time control for the PGN TimeControl tag, or "-" when
+// moves aren't timed by a clock.
+func (tc TimeControl) String() string {
+	if tc.Time == 0 {
+		return "-"
+	}
+
+	s := fmt.Sprintf("%g", tc.Time.Seconds())
+
+	if tc.Moves > 0 {
+		s = fmt.Sprintf("%d/%s", tc.Moves, s)
+	}
+
+	if tc.Inc > 0 {
+		s += fmt.Sprintf("+%g", tc.Inc.Seconds())
+	}
+
+	return s
+}
@@ -0,0 +1,107 @@
+package match
+
+import (
+	"../chess"
+	"../epd"
+	"../fen"
+	"../pgn"
+)
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// An Opening is where the games of a pair start: a position, and the
+// moves played from it before the engines take over.
+type Opening struct {
+	Name string
+	Game *chess.Game
+	Moves []*chess.Move
+}
+
+// StartPosition is the opening when a match has none.
+var StartPosition = &Opening{ Game: chess.NewGame() }
+
+// LoadOpenings reads openings from an EPD file (positions only), or a
+// PGN file with at most plies moves of each game (all if 0).
+func LoadOpenings(filename string, plies int) ([]*Opening, error) {
+	if strings.ToLower(filepath.Ext(filename)) == ".epd" {
+		return loadEPD(filename)
+	}
+
+	return loadPGN(filename, plies)
+}
+
+func loadEPD(filename string) ([]*Opening, error) {
+	positions, err := epd.ParseFile(filename)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	openings := make([]*Opening, len(positions))
+
+	for i, e := range positions {
+		openings[i] = &Opening{ Name: e.ID, Game: e.Game }
+	}
+
+	return openings, nil
+}
+
+func loadPGN(filename string, plies int) ([]*Opening, error) {
+	f, err := os.Open(filename)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	games := make(chan *pgn.PGN)
+	openings := make([]*Opening, 0, 64)
+
+	go pgn.Read(f, games, &err)
+
+	for game := range games {
+		o := &Opening{ Name: game.Tags["Opening"], Game: game.Setup() }
+
+		// games of unknown variants are skipped
+		if o.Game == nil {
+			continue
+		}
+
+		if v := game.Tags["Variation"]; v != "" {
+			o.Name += ": " + v
+		}
+
+		for _, pair := range game.Moves {
+			for _, m := range pair {
+				if m != nil && (plies == 0 || len(o.Moves) < plies) {
+					o.Moves = append(o.Moves, m.Move)
+				}
+			}
+		}
+
+		openings = append(openings, o)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", filename, err)
+	}
+
+	return openings, nil
+}
+
+// fen of the starting position, empty when it's the standard start
+func (o *Opening) fen() string {
+	s := fen.Format(o.Game)
+
+	if s == fen.Start && o.Game.IsStandard() {
+		return ""
+	}
+
+	return s
+}
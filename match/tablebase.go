@@ -0,0 +1,111 @@
+package match
+
+import (
+	"../chess"
+	"../fen"
+)
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// A Server is a Tablebase probed over HTTP with the API of lichess's
+// tablebase, e.g. https://tablebase.lichess.ovh/standard. Only
+// standard chess positions of up to Pieces pieces without castling
+// rights are probed, and each position only once. A failed request
+// doesn't adjudicate the game.
+type Server struct {
+	URL string
+	Pieces int                    // most pieces on the board, 7 if 0
+	Client *http.Client           // 5 second timeout if nil
+
+	lock sync.Mutex
+	cache map[string]chess.Outcome
+}
+
+// the part of a lichess tablebase response needed
+type probe struct {
+	Category string `json:"category"`
+}
+
+func (s *Server) Probe(g *chess.Game) (chess.Outcome, bool) {
+	pieces := s.Pieces
+
+	if pieces <= 0 {
+		pieces = 7
+	}
+
+	switch {
+		case g.IsStandard() == false:
+			return chess.InProgress, false
+		case g.Castles != 0 || g.Bits.Occupied.Count() > pieces:
+			return chess.InProgress, false
+	}
+
+	key := fen.Format(g)
+
+	s.lock.Lock()
+	outcome, ok := s.cache[key]
+	s.lock.Unlock()
+
+	if ok {
+		return outcome, outcome != chess.InProgress
+	}
+
+	outcome, ok = s.request(g, key)
+
+	// errors are retried the next time the position is probed
+	if ok {
+		s.lock.Lock()
+
+		if s.cache == nil {
+			s.cache = make(map[string]chess.Outcome)
+		}
+
+		s.cache[key] = outcome
+		s.lock.Unlock()
+	}
+
+	return outcome, outcome != chess.InProgress
+}
+
+// request asks the server about a position, true if it answered.
+func (s *Server) request(g *chess.Game, key string) (chess.Outcome, bool) {
+	client := s.Client
+
+	if client == nil {
+		client = &http.Client{ Timeout: 5 * time.Second }
+	}
+
+	resp, err := client.Get(s.URL + "?" + url.Values{ "fen": { key } }.Encode())
+
+	if err != nil {
+		return chess.InProgress, false
+	}
+
+	defer resp.Body.Close()
+
+	var p probe
+
+	if resp.StatusCode != http.StatusOK || json.NewDecoder(resp.Body).Decode(&p) != nil {
+		return chess.InProgress, false
+	}
+
+	// categories are for the player to move, cursed wins and blessed
+	// losses are drawn by the fifty moves rule
+	switch p.Category {
+		case "win":
+			return chess.Win(g.Turn), true
+		case "loss":
+			return chess.Win(g.Turn.Opponent()), true
+		case "draw", "cursed-win", "blessed-loss":
+			return chess.Draw, true
+	}
+
+	// unknown, or uncertain because of the fifty moves rule
+	return chess.InProgress, true
+}
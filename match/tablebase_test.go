@@ -0,0 +1,76 @@
+package match_test
+
+import (
+	"../chess"
+	"../fen"
+	"../match"
+)
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestServer(t *testing.T) {
+	categories := map[string]string{
+		"4k3/8/8/8/8/8/8/3QK3 w - - 0 1": "win",
+		"4k3/8/8/8/8/8/8/3QK3 b - - 0 1": "loss",
+		"4k3/8/8/8/8/8/8/3NK3 w - - 0 1": "draw",
+		"4k3/8/8/8/8/8/8/2NNK3 w - - 90 1": "cursed-win",
+		"4k3/8/8/8/8/8/8/1NNNK3 w - - 99 1": "maybe-win",
+	}
+
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if c, ok := categories[r.URL.Query().Get("fen")]; ok {
+			fmt.Fprintf(w, `{"category":%q,"dtz":1,"moves":[]}`, c)
+		} else {
+			http.NotFound(w, r)
+		}
+	}))
+
+	defer server.Close()
+
+	tb := &match.Server{ URL: server.URL }
+
+	cases := []struct {
+		fen string
+		outcome chess.Outcome
+		ok bool
+	}{
+		{ "4k3/8/8/8/8/8/8/3QK3 w - - 0 1", chess.WhiteWins, true },
+		{ "4k3/8/8/8/8/8/8/3QK3 b - - 0 1", chess.WhiteWins, true },
+		{ "4k3/8/8/8/8/8/8/3NK3 w - - 0 1", chess.Draw, true },
+		{ "4k3/8/8/8/8/8/8/2NNK3 w - - 90 1", chess.Draw, true },
+		{ "4k3/8/8/8/8/8/8/1NNNK3 w - - 99 1", chess.InProgress, false },
+		{ "4k3/8/8/8/8/8/8/4K2R w K - 0 1", chess.InProgress, false },
+		{ "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1", chess.InProgress, false },
+		{ "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", chess.InProgress, false },
+	}
+
+	for _, c := range cases {
+		outcome, ok := tb.Probe(fen.Parse(c.fen))
+
+		if outcome != c.outcome || ok != c.ok {
+			t.Errorf("%s: probed %v %v, want %v %v", c.fen, outcome, ok, c.outcome, c.ok)
+		}
+	}
+
+	// castling rights and too many pieces aren't sent, known results
+	// are cached and only failed requests are retried
+	if requests != 6 {
+		t.Errorf("%d requests, want 6", requests)
+	}
+
+	tb.Probe(fen.Parse("4k3/8/8/8/8/8/8/3QK3 w - - 0 1"))
+	tb.Probe(fen.Parse("4k3/8/8/8/8/8/8/R3K3 w - - 0 1"))
+
+	if requests != 7 {
+		t.Errorf("%d requests, want 7", requests)
+	}
+}
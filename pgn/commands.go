@@ -0,0 +1,150 @@
+package pgn
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Eval is an engine evaluation from white's point of view, as
+// written in a [%eval] comment command.
+type Eval struct {
+	Centipawns int            // score when not a mate
+	Mate int                  // moves to mate, negative if black mates
+}
+
+// commands embedded in comments, e.g. [%eval 0.25], [%eval #-3] or
+// [%clk 1:29:55]
+var reCommand = regexp.MustCompile("\\[%(\\w+)\\s+([^\\]]*)\\]")
+
+// parseCommands takes the known commands out of a comment.
+func (m *Move) parseCommands() {
+	m.Comment = reCommand.ReplaceAllStringFunc(m.Comment, func(s string) string {
+		cmd := reCommand.FindStringSubmatch(s)
+
+		switch cmd[1] {
+			case "eval":
+				if eval, ok := ParseEval(cmd[2]); ok {
+					m.Eval = eval
+					return ""
+				}
+				break
+			case "clk":
+				if clock, ok := ParseClock(cmd[2]); ok {
+					m.Clock = &clock
+					return ""
+				}
+				break
+		}
+
+		return s
+	})
+
+	m.Comment = strings.TrimSpace(m.Comment)
+}
+
+// formatComment writes a comment with the commands of the move.
+func (m *Move) formatComment() string {
+	s := m.Comment
+
+	if m.Clock != nil {
+		s = strings.TrimSpace(fmt.Sprintf("[%%clk %s] %s", FormatClock(*m.Clock), s))
+	}
+
+	if m.Eval != nil {
+		s = strings.TrimSpace(fmt.Sprintf("[%%eval %s] %s", m.Eval, s))
+	}
+
+	return s
+}
+
+// ParseEval reads an evaluation in pawns (0.25) or a mate (#-3).
+func ParseEval(s string) (*Eval, bool) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "#") {
+		n, err := strconv.Atoi(s[1:])
+
+		if err != nil {
+			return nil, false
+		}
+
+		return &Eval{Mate: n}, true
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+
+	if err != nil {
+		return nil, false
+	}
+
+	// round to the nearest centipawn
+	if f < 0 {
+		return &Eval{Centipawns: int(f * 100 - 0.5)}, true
+	}
+
+	return &Eval{Centipawns: int(f * 100 + 0.5)}, true
+}
+
+func (e *Eval) String() string {
+	if e.Mate != 0 {
+		return fmt.Sprintf("#%d", e.Mate)
+	}
+
+	return fmt.Sprintf("%.2f", float64(e.Centipawns) / 100)
+}
+
+// ParseClock reads the time on a clock as h:mm:ss, optionally with
+// fractions of a second (0:00:09.5).
+func ParseClock(s string) (time.Duration, bool) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+
+	if len(parts) > 3 {
+		return 0, false
+	}
+
+	var d time.Duration
+
+	for i, part := range parts {
+		if i < len(parts) - 1 {
+			n, err := strconv.Atoi(part)
+
+			if err != nil || n < 0 {
+				return 0, false
+			}
+
+			d = (d + time.Duration(n)) * 60
+			continue
+		}
+
+		f, err := strconv.ParseFloat(part, 64)
+
+		if err != nil || f < 0 {
+			return 0, false
+		}
+
+		d = d * time.Second + time.Duration(f * float64(time.Second) + 0.5)
+	}
+
+	return d, true
+}
+
+// FormatClock writes the time on a clock as h:mm:ss, with tenths of a
+// second when there are any.
+func FormatClock(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+
+	d = d.Round(time.Second / 10)
+
+	s := fmt.Sprintf("%d:%02d:%02d", d / time.Hour, d / time.Minute % 60, d / time.Second % 60)
+
+	if tenths := d / (time.Second / 10) % 10; tenths != 0 {
+		s += fmt.Sprintf(".%d", tenths)
+	}
+
+	return s
+}
@@ -4,16 +4,251 @@ import "../chess"
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
 )
 
 type errno int
 
 const (
-	InvalidMoveString = errno(1)
+	InvalidMoveString = errno(1 + iota)
+	IllegalMove
+	UnknownVariant
+	UnbalancedVariation
 )
 
 var errmap = map[errno]string{
 	InvalidMoveString: "Invalid move string",
+	IllegalMove: "Illegal move",
+	UnknownVariant: "Unknown variant",
+	UnbalancedVariation: "Unbalanced variation",
 }
 
-// regular expression for parsing a move string
+func (e errno) Error() string {
+	if msg, ok := errmap[e]; ok {
+		return msg
+	}
+	return "Unknown error"
+}
+
+// regular expressions for each token of the movetext
+var reComment = regexp.MustCompile("^(?:\\{([^}]*)\\}|;([^\\n]*))")
+var reNAG = regexp.MustCompile("^\\$(\\d+)")
+var reMoveNumber = regexp.MustCompile("^\\d+\\.(?:\\.\\.)?")
+var reResult = regexp.MustCompile("^(?:1-0|0-1|1/2-1/2|\\*)")
+var reSAN = regexp.MustCompile("^([^\\s{}();$!?]+)([!?]*)")
+
+// suffix annotations and their equivalent NAGs
+var suffixNAGs = map[string]int{
+	"!": 1,
+	"?": 2,
+	"!!": 3,
+	"??": 4,
+	"!?": 5,
+	"?!": 6,
+}
+
+var results = map[string]int{
+	"1-0": WhiteWins,
+	"0-1": BlackWins,
+	"1/2-1/2": Draw,
+	"*": InProgress,
+}
+
+// ParseMoves reads the movetext of a game up to its result. Parsing
+// variations is lossy: only the first variation of a move is kept, as
+// its Alternative, and without its comments and NAGs. Later variations
+// of the move and variations nested inside them are dropped, so
+// writing a game back out doesn't reproduce them.
+func (pgn *PGN) ParseMoves(text *[]byte) error {
+	g := pgn.Setup()
+
+	if g == nil {
+		return UnknownVariant
+	}
+
+	// the last move played and the position before it
+	var last *Move
+	var before chess.Game
+
+	pgn.Moves = make([][2]*Move, 0, 40)
+	pgn.Result = results[pgn.Tags["Result"]]
+
+	for {
+		skipWhitespace(text)
+
+		if len(*text) == 0 {
+			return nil
+		}
+
+		// end of game
+		if m := reResult.Find(*text); m != nil {
+			pgn.Result = results[string(m)]
+			*text = (*text)[len(m):]
+			return nil
+		}
+
+		// comments belong to the last move, or the game
+		if m := reComment.FindSubmatch(*text); m != nil {
+			comment := strings.Join(strings.Fields(string(m[1]) + string(m[2])), " ")
+
+			if last != nil {
+				last.Comment = joinComment(last.Comment, comment)
+				last.parseCommands()
+			} else {
+				pgn.Comment = joinComment(pgn.Comment, comment)
+			}
+
+			*text = (*text)[len(m[0]):]
+			continue
+		}
+
+		if m := reNAG.FindSubmatch(*text); m != nil {
+			if n, err := strconv.Atoi(string(m[1])); err == nil && last != nil {
+				last.NAGs = append(last.NAGs, n)
+			}
+
+			*text = (*text)[len(m[0]):]
+			continue
+		}
+
+		if m := reMoveNumber.Find(*text); m != nil {
+			*text = (*text)[len(m):]
+			continue
+		}
+
+		// a variation replaces the last move
+		if (*text)[0] == '(' {
+			*text = (*text)[1:]
+
+			alt, err := parseVariation(text, last, before)
+
+			if err != nil {
+				return err
+			}
+
+			if last != nil && last.Alternative == nil {
+				last.Alternative = alt
+			}
+
+			continue
+		}
+
+		m := reSAN.FindSubmatch(*text)
+
+		if m == nil {
+			return InvalidMoveString
+		}
+
+		move := g.ParseMove(string(m[1]))
+
+		if move == nil {
+			return IllegalMove
+		}
+
+		last = &Move{Move: move}
+		before = *g
+
+		if nag, ok := suffixNAGs[string(m[2])]; ok {
+			last.NAGs = append(last.NAGs, nag)
+		}
+
+		// white starts a new pair of moves
+		if g.Turn == chess.White || len(pgn.Moves) == 0 {
+			pgn.Moves = append(pgn.Moves, [2]*Move{})
+		}
+
+		pgn.Moves[len(pgn.Moves) - 1][g.Turn] = last
+
+		g.PerformMove(move)
+		*text = (*text)[len(m[0]):]
+	}
+}
+
+// parseVariation reads the moves up to the closing parenthesis,
+// played from the position before the last move. Only the moves of
+// the variation itself are kept; nested variations are skipped.
+func parseVariation(text *[]byte, last *Move, before chess.Game) ([]*chess.Move, error) {
+	var g *chess.Game
+
+	if last != nil {
+		g = &before
+	}
+
+	moves := make([]*chess.Move, 0, 8)
+	depth := 0
+
+	for {
+		skipWhitespace(text)
+
+		if len(*text) == 0 {
+			return nil, UnbalancedVariation
+		}
+
+		switch (*text)[0] {
+			case '(':
+				depth++
+				*text = (*text)[1:]
+				continue
+			case ')':
+				*text = (*text)[1:]
+
+				if depth == 0 {
+					return moves, nil
+				}
+
+				depth--
+				continue
+		}
+
+		if m := reComment.Find(*text); m != nil {
+			*text = (*text)[len(m):]
+			continue
+		}
+
+		if m := reNAG.Find(*text); m != nil {
+			*text = (*text)[len(m):]
+			continue
+		}
+
+		if m := reMoveNumber.Find(*text); m != nil {
+			*text = (*text)[len(m):]
+			continue
+		}
+
+		m := reSAN.FindSubmatch(*text)
+
+		if m == nil {
+			return nil, InvalidMoveString
+		}
+
+		*text = (*text)[len(m[0]):]
+
+		// moves of nested variations are skipped
+		if depth > 0 || g == nil {
+			continue
+		}
+
+		move := g.ParseMove(string(m[1]))
+
+		if move == nil {
+			return nil, IllegalMove
+		}
+
+		moves = append(moves, move)
+		g.PerformMove(move)
+	}
+}
+
+func skipWhitespace(text *[]byte) {
+	if m := reWhitespace.Find(*text); m != nil {
+		*text = (*text)[len(m):]
+	}
+}
+
+func joinComment(comment, s string) string {
+	if comment == "" {
+		return s
+	}
+	return comment + " " + s
+}
@@ -1,10 +1,19 @@
 package pgn
 
-import "../chess"
+import (
+	"../chess"
+	"../fen"
+)
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"regexp"
+	"strings"
+	"time"
 )
 
 type PGN struct {
@@ -16,8 +25,11 @@ type PGN struct {
 
 type Move struct {
 	Move *chess.Move            // actual move
-	Alternative []*chess.Move   // alternative line of moves
+	Alternative []*chess.Move   // first variation, see ParseMoves
 	Comment string              // optional comment
+	NAGs []int                  // numeric annotation glyphs
+	Eval *Eval                  // engine evaluation after the move
+	Clock *time.Duration        // time left after the move
 }
 
 const (
@@ -28,7 +40,7 @@ const (
 )
 
 var reTagPair, _ = regexp.Compile("^\\[([^\\s\t]+)\\s*\"([^\"]*)\"\\]")
-var reWhitespace, _ = regexp.Compile("[\\s\\n]*")
+var reWhitespace, _ = regexp.Compile("^[\\s\\n]*")
 
 func Parse(filename string) ([]*PGN, error) {
 	bytes, err := ioutil.ReadFile(filename)
@@ -68,6 +80,89 @@ func ParseGames(ch chan *PGN, text []byte, err *error) {
 	close(ch)
 }
 
+// A GameError is a game of a stream that couldn't be parsed.
+type GameError struct {
+	Game int                    // number of the game in the stream, from 1
+	Line int                    // line the game starts on
+	Err error
+}
+
+func (e *GameError) Error() string {
+	return fmt.Sprintf("game %d at line %d: %s", e.Game, e.Line, e.Err)
+}
+
+// Skipped is the games that Read couldn't parse and left out.
+type Skipped []*GameError
+
+// Error lists the games skipped, one per line.
+func (s Skipped) Error() string {
+	lines := make([]string, len(s))
+
+	for i, e := range s {
+		lines[i] = "skipped " + e.Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Read parses games from a reader one at a time, so that large
+// databases don't have to fit in memory. A game that can't be parsed
+// is skipped and the rest are still read, then err is the Skipped
+// games. Any other error reading stops the stream.
+func Read(r io.Reader, ch chan *PGN, err *error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64 * 1024), 16 * 1024 * 1024)
+
+	// the text of the current game
+	var text []byte
+	var moves bool
+	var skipped Skipped
+
+	// where the current game started
+	n, lines, start := 0, 0, 1
+
+	parse := func() {
+		if len(bytes.TrimSpace(text)) > 0 {
+			n++
+
+			if game, perr := ParseGame(&text); perr != nil {
+				skipped = append(skipped, &GameError{ Game: n, Line: start, Err: perr })
+			} else {
+				ch <- game
+			}
+		}
+
+		text, moves, start = text[:0], false, lines
+	}
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		lines++
+
+		// a tag pair after movetext starts the next game
+		if len(line) > 0 && line[0] == '[' && moves {
+			parse()
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 && line[0] != '[' {
+			moves = true
+		}
+
+		text = append(text, line...)
+		text = append(text, '\n')
+	}
+
+	if *err = scanner.Err(); *err == nil {
+		parse()
+
+		if len(skipped) > 0 {
+			*err = skipped
+		}
+	}
+
+	close(ch)
+}
+
 func ParseGame(text *[]byte) (*PGN, error) {
 	var err error
 
@@ -76,6 +171,10 @@ func ParseGame(text *[]byte) (*PGN, error) {
 
 	// parse the various sections
 	if err = pgn.ParseTagPairs(text); err != nil { return nil, err }
+	if err = pgn.ParseMoves(text); err != nil { return nil, err }
+
+	// skip to the next game
+	skipWhitespace(text)
 
 	return pgn, nil
 }
@@ -84,6 +183,8 @@ func (pgn *PGN) ParseTagPairs(text *[]byte) error {
 	pgn.Tags = make(map[string]string)
 
 	for {
+		skipWhitespace(text)
+
 		match := reTagPair.FindSubmatch(*text)
 
 		if match == nil {
@@ -96,6 +197,24 @@ func (pgn *PGN) ParseTagPairs(text *[]byte) error {
 		// advance the pointer
 		*text = (*text)[len(match[0]):]
 	}
+}
+
+func (pgn *PGN) Variant() chess.Variant {
+	return chess.VariantByName(pgn.Tags["Variant"])
+}
+
+func (pgn *PGN) Setup() *chess.Game {
+	v := pgn.Variant()
+
+	// unknown variants can't be played
+	if v == nil {
+		return nil
+	}
+
+	// games may start from a custom position
+	if setup, ok := pgn.Tags["FEN"]; ok {
+		return fen.ParseVariant(setup, v)
+	}
 
-	return nil
+	return chess.NewVariantGame(v)
 }
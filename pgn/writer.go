@@ -0,0 +1,186 @@
+package pgn
+
+import "../chess"
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// the seven tag roster comes first, in this order
+var roster = []string{ "Event", "Site", "Date", "Round", "White", "Black", "Result" }
+
+var resultTokens = map[int]string{
+	InProgress: "*",
+	Draw: "1/2-1/2",
+	WhiteWins: "1-0",
+	BlackWins: "0-1",
+}
+
+// Write writes games as PGN.
+func Write(w io.Writer, games ...*PGN) error {
+	for _, game := range games {
+		if _, err := io.WriteString(w, game.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// String formats the game as PGN: the tags, then the movetext with
+// comments, NAGs and the variation of each move kept by ParseMoves,
+// followed by a blank line.
+func (pgn *PGN) String() string {
+	var b strings.Builder
+
+	result := resultTokens[pgn.Result]
+
+	// tags of the roster, then the rest sorted
+	for _, tag := range roster {
+		value, ok := pgn.Tags[tag]
+
+		switch {
+			case tag == "Result":
+				value = result
+				break
+			case ok == false:
+				value = "?"
+				break
+		}
+
+		fmt.Fprintf(&b, "[%s \"%s\"]\n", tag, escape(value))
+	}
+
+	tags := make([]string, 0, len(pgn.Tags))
+
+	for tag := range pgn.Tags {
+		if isRoster(tag) == false {
+			tags = append(tags, tag)
+		}
+	}
+
+	sort.Strings(tags)
+
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "[%s \"%s\"]\n", tag, escape(pgn.Tags[tag]))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(wrap(pgn.movetext(result), 80))
+	b.WriteString("\n\n")
+
+	return b.String()
+}
+
+func isRoster(tag string) bool {
+	for _, t := range roster {
+		if t == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func escape(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"").Replace(s)
+}
+
+func (pgn *PGN) movetext(result string) string {
+	tokens := make([]string, 0, len(pgn.Moves) * 3)
+
+	if pgn.Comment != "" {
+		tokens = append(tokens, "{" + pgn.Comment + "}")
+	}
+
+	g := pgn.Setup()
+
+	// an unknown variant can't be replayed to write SAN
+	if g == nil {
+		return result
+	}
+
+	// a move number is needed for black after anything else
+	number := true
+
+	for _, pair := range pgn.Moves {
+		for _, m := range pair {
+			if m == nil {
+				continue
+			}
+
+			tokens = append(tokens, moveTokens(g, m.Move, number)...)
+			number = false
+
+			for _, nag := range m.NAGs {
+				tokens = append(tokens, fmt.Sprintf("$%d", nag))
+			}
+
+			if c := m.formatComment(); c != "" {
+				tokens = append(tokens, "{" + c + "}")
+				number = true
+			}
+
+			// the variation replaces this move
+			if len(m.Alternative) > 0 {
+				tokens = append(tokens, variation(*g, m.Alternative))
+				number = true
+			}
+
+			g.PerformMove(m.Move)
+		}
+	}
+
+	return strings.Join(append(tokens, result), " ")
+}
+
+// moveTokens is the SAN of a move, with the move number before it
+// for white, or for black when forced.
+func moveTokens(g *chess.Game, move *chess.Move, number bool) []string {
+	san := g.SAN(move)
+
+	switch {
+		case g.Turn == chess.White:
+			return []string{ fmt.Sprintf("%d.", g.Move), san }
+		case number:
+			return []string{ fmt.Sprintf("%d...", g.Move), san }
+	}
+
+	return []string{ san }
+}
+
+func variation(g chess.Game, moves []*chess.Move) string {
+	tokens := make([]string, 0, len(moves) + 2)
+
+	for i, move := range moves {
+		tokens = append(tokens, moveTokens(&g, move, i == 0)...)
+		g.PerformMove(move)
+	}
+
+	return "(" + strings.Join(tokens, " ") + ")"
+}
+
+// wrap breaks text into lines no longer than width, between tokens.
+func wrap(text string, width int) string {
+	var b strings.Builder
+
+	n := 0
+
+	for _, word := range strings.Split(text, " ") {
+		if n > 0 && n + 1 + len(word) > width {
+			b.WriteString("\n")
+			n = 0
+		} else if n > 0 {
+			b.WriteString(" ")
+			n++
+		}
+
+		b.WriteString(word)
+		n += len(word)
+	}
+
+	return b.String()
+}
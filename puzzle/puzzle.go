@@ -0,0 +1,315 @@
+package puzzle
+
+import (
+	"../chess"
+	"../fen"
+	"../pgn"
+	"../search"
+	"../tactics"
+)
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A Puzzle is a position where exactly one move wins (or saves the
+// game), followed by a solution line that is unique at every move
+// of the player solving it.
+type Puzzle struct {
+	FEN string                // position with the solver to move
+	Moves []chess.Move        // solution line, alternating with replies
+	UCI []string              // solution in UCI notation
+	SAN []string              // solution in standard algebraic notation
+	Rating int                // estimated difficulty
+	Themes []string           // tactical motifs and puzzle kind
+	Site string               // where the game was played
+}
+
+// Options control how hard positions are searched and which advantage
+// counts as winning.
+type Options struct {
+	Depth int                 // search depth for each position
+	Nodes int                 // node limit for each search
+	Winning int               // score that wins decisively
+	Equal int                 // score that's still holding the game
+	MaxMoves int              // longest solution, in moves of the solver
+}
+
+var DefaultOptions = Options{
+	Depth: 6,
+	Winning: 300,
+	Equal: 100,
+	MaxMoves: 6,
+}
+
+type Finder struct {
+	Options Options
+	searcher *search.Searcher
+}
+
+func NewFinder(opts Options) *Finder {
+	return &Finder{
+		Options: opts,
+		searcher: search.New(),
+	}
+}
+
+// Find replays a game and returns every puzzle found in it.
+func (f *Finder) Find(game *pgn.PGN) []*Puzzle {
+	g := game.Setup()
+	puzzles := make([]*Puzzle, 0, 1)
+
+	if g == nil {
+		return puzzles
+	}
+
+	// score of the previous position, for the player who was to move
+	prev := 0
+	skip := 0
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m == nil {
+				continue
+			}
+
+			result := f.search(g, nil)
+
+			// a position was already winning before the opponent's move
+			// isn't an opportunity that arose from a mistake
+			if skip > 0 {
+				skip--
+			} else if -prev < f.Options.Winning {
+				if p := f.puzzle(g, result); p != nil {
+					p.Site = game.Tags["Site"]
+					puzzles = append(puzzles, p)
+
+					// don't find the same puzzle again along the line
+					skip = len(p.Moves)
+				}
+			}
+
+			prev = result.Score
+			g.PerformMove(m.Move)
+		}
+	}
+
+	return puzzles
+}
+
+// Solve checks whether a position is a puzzle and returns it.
+func (f *Finder) Solve(g *chess.Game) *Puzzle {
+	return f.puzzle(g, f.search(g, nil))
+}
+
+func (f *Finder) search(g *chess.Game, moves []chess.Move) search.Result {
+	return f.searcher.Search(g, search.Limits{
+		Depth: f.Options.Depth,
+		Nodes: f.Options.Nodes,
+		SearchMoves: moves,
+	})
+}
+
+// second searches every move except the best one, which is how the
+// uniqueness of the best move is checked.
+func (f *Finder) second(g *chess.Game, best *chess.Move) (search.Result, bool) {
+	var list chess.MoveList
+
+	g.GenerateMoves(&list)
+	list.Filter(func(move *chess.Move) bool {
+		return search.SameMove(move, best) == false
+	})
+
+	if list.Len == 0 {
+		return search.Result{}, false
+	}
+
+	return f.search(g, list.Slice()), true
+}
+
+// unique is true when the best move wins and the second best doesn't,
+// or the best move holds the game and the second best loses.
+func (f *Finder) unique(best, second int) bool {
+	switch {
+		case best >= f.Options.Winning:
+			return second < f.Options.Equal || search.IsMate(best) && !search.IsMate(second)
+		case best > -f.Options.Equal:
+			return second <= -f.Options.Winning
+	}
+
+	return false
+}
+
+func (f *Finder) puzzle(g *chess.Game, result search.Result) *Puzzle {
+	if len(result.PV) == 0 {
+		return nil
+	}
+
+	// there has to be a choice for the first move
+	second, ok := f.second(g, &result.Move)
+
+	if ok == false || f.unique(result.Score, second.Score) == false {
+		return nil
+	}
+
+	mate := search.IsMate(result.Score)
+	saving := result.Score < f.Options.Winning
+
+	p := &Puzzle{
+		FEN: fen.Format(g),
+		Moves: []chess.Move{ result.Move },
+	}
+
+	pos := *g
+	pos.PerformMove(&result.Move)
+
+	// extend the line while the solver's move is the only one
+	for len(p.Moves) < f.Options.MaxMoves * 2 - 1 && saving == false {
+		if pos.Outcome() != chess.InProgress {
+			break
+		}
+
+		reply := f.search(&pos, nil)
+
+		if len(reply.PV) == 0 {
+			break
+		}
+
+		next := pos
+		next.PerformMove(&reply.Move)
+
+		if next.Outcome() != chess.InProgress {
+			break
+		}
+
+		answer := f.search(&next, nil)
+
+		if len(answer.PV) == 0 {
+			break
+		}
+
+		if alt, ok := f.second(&next, &answer.Move); ok {
+			if f.unique(answer.Score, alt.Score) == false {
+				// a mate has to be unique all the way, a material gain
+				// is complete once the solver has a choice
+				if mate {
+					return nil
+				}
+				break
+			}
+		}
+
+		p.Moves = append(p.Moves, reply.Move, answer.Move)
+
+		pos = next
+		pos.PerformMove(&answer.Move)
+	}
+
+	// a mating line has to end in mate
+	if mate && pos.Outcome() != chess.Win(g.Turn) {
+		return nil
+	}
+
+	p.annotate(g, mate, saving, result.Score)
+
+	return p
+}
+
+func (p *Puzzle) annotate(g *chess.Game, mate, saving bool, score int) {
+	pos := *g
+	themes := make(map[string]bool)
+
+	p.UCI = make([]string, len(p.Moves))
+	p.SAN = make([]string, len(p.Moves))
+
+	for i := range p.Moves {
+		move := &p.Moves[i]
+
+		// motifs of the solver's moves
+		if i & 1 == 0 {
+			for _, theme := range tactics.Themes(tactics.Detect(&pos, move)) {
+				themes[theme.String()] = true
+			}
+		}
+
+		p.UCI[i] = move.UCI()
+		p.SAN[i] = pos.SAN(move)
+
+		pos.PerformMove(move)
+	}
+
+	// the kind of puzzle
+	switch {
+		case mate:
+			themes["mate"] = true
+			themes["mateIn" + strconv.Itoa(len(p.Moves) / 2 + 1)] = true
+			break
+		case saving:
+			themes["equality"] = true
+			break
+		case score >= 600:
+			themes["crushing"] = true
+			break
+		default:
+			themes["advantage"] = true
+			break
+	}
+
+	switch n := len(p.Moves) / 2 + 1; {
+		case n == 1: themes["oneMove"] = true; break
+		case n == 2: themes["short"] = true; break
+		case n <= 4: themes["long"] = true; break
+		default:     themes["veryLong"] = true; break
+	}
+
+	for theme := range themes {
+		p.Themes = append(p.Themes, theme)
+	}
+
+	sort.Strings(p.Themes)
+
+	p.Rating = rate(g, p, saving)
+}
+
+// rate estimates how hard a puzzle is: longer lines, quiet first moves,
+// sacrifices and defensive puzzles are all harder to find.
+func rate(g *chess.Game, p *Puzzle, saving bool) int {
+	first := &p.Moves[0]
+	rating := 1000 + 150 * (len(p.Moves) / 2)
+
+	if first.Capture == false && g.GivesCheck(first) == false {
+		rating += 300
+	}
+
+	// giving up material to win
+	if g.SEE(first) < 0 {
+		rating += 250
+	}
+
+	if saving {
+		rating += 200
+	}
+
+	rating += 50 * len(p.Themes)
+
+	if rating > 3000 {
+		rating = 3000
+	}
+
+	return rating
+}
+
+// Record returns the puzzle as a CSV record of the FEN, UCI moves,
+// SAN moves, rating, themes and site.
+func (p *Puzzle) Record() []string {
+	return []string{
+		p.FEN,
+		strings.Join(p.UCI, " "),
+		strings.Join(p.SAN, " "),
+		strconv.Itoa(p.Rating),
+		strings.Join(p.Themes, " "),
+		p.Site,
+	}
+}
@@ -0,0 +1,317 @@
+package render
+
+import (
+	"../chess"
+	"../pgn"
+)
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"math"
+	"time"
+)
+
+var ErrSetup = errors.New("the game's starting position is invalid")
+
+// ImageOptions change how a board is drawn as a picture.
+type ImageOptions struct {
+	Size int                  // width and height in pixels, rounded down to a multiple of 8
+	Theme *Theme              // colors of the squares, brown if nil
+	Flip bool                 // black at the bottom
+	Coordinates bool          // ranks and files on the edge squares
+	Check bool                // highlight a king in check
+	LastMove *chess.Move      // highlight the squares it moved between
+	Highlights []Marker       // squares filled with a color
+	Arrows []Arrow
+	Markers []Marker          // squares circled, as with [%csl]
+}
+
+var DefaultImage = ImageOptions{
+	Size: 360,
+	Coordinates: true,
+	Check: true,
+}
+
+// Image draws the position as a picture, the same as the SVG diagram.
+func Image(g *chess.Game, opts ImageOptions) *image.RGBA {
+	return newPainter(opts).paint(g)
+}
+
+// PNG writes a picture of the position.
+func PNG(w io.Writer, g *chess.Game, opts ImageOptions) error {
+	return png.Encode(w, Image(g, opts))
+}
+
+// GIF writes an animation of a game, from the starting position to the
+// end, showing each position for a delay with the move played to reach
+// it highlighted. The arrows and circles of a move's [%cal] and [%csl]
+// comments are drawn with it. The final position is shown for longer.
+func GIF(w io.Writer, game *pgn.PGN, opts ImageOptions, delay time.Duration) error {
+	p := newPainter(opts)
+	g := game.Setup()
+
+	if g == nil {
+		return ErrSetup
+	}
+
+	anim := &gif.GIF{}
+	palette := p.palette()
+	index := make(map[color.RGBA]uint8)
+
+	frame := func(m *pgn.Move) {
+		p.opts.LastMove, p.opts.Arrows, p.opts.Markers = nil, nil, nil
+
+		if m != nil {
+			p.opts.LastMove = m.Move
+			p.opts.Arrows, p.opts.Markers = Annotations(m.Comment)
+		}
+
+		img := p.paint(g)
+		frame := image.NewPaletted(img.Bounds(), palette)
+
+		// few colors are ever used, so remember the closest of each
+		for i := 0; i < len(img.Pix); i += 4 {
+			c := color.RGBA{ img.Pix[i], img.Pix[i + 1], img.Pix[i + 2], 255 }
+			n, ok := index[c]
+
+			if ok == false {
+				n = uint8(palette.Index(c))
+				index[c] = n
+			}
+
+			frame.Pix[i / 4] = n
+		}
+
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, int(delay / (10 * time.Millisecond)))
+	}
+
+	frame(nil)
+
+	for _, pair := range game.Moves {
+		for _, m := range pair {
+			if m != nil {
+				g.PerformMove(m.Move)
+				frame(m)
+			}
+		}
+	}
+
+	anim.Delay[len(anim.Delay) - 1] *= 3
+
+	return gif.EncodeAll(w, anim)
+}
+
+// A painter draws positions, keeping the pieces it has drawn so that
+// it can draw many positions quickly.
+type painter struct {
+	opts ImageOptions
+	square int
+	pieces map[chess.Piece]*image.RGBA
+}
+
+func newPainter(opts ImageOptions) *painter {
+	size := opts.Size
+
+	if size <= 0 {
+		size = DefaultImage.Size
+	}
+
+	return &painter{
+		opts: opts,
+		square: max(size / 8, 8),
+		pieces: make(map[chess.Piece]*image.RGBA),
+	}
+}
+
+func (p *painter) paint(g *chess.Game) *image.RGBA {
+	t := theme(p.opts.Theme)
+	l := layout{ flip: p.opts.Flip }
+
+	img := image.NewRGBA(image.Rect(0, 0, p.square * 8, p.square * 8))
+	c := &canvas{ img: img, scale: float64(p.square) / unit }
+
+	for sq := 0; sq < 64; sq++ {
+		tile := chess.SquareTile(sq)
+		moved := p.opts.LastMove != nil && (tile == p.opts.LastMove.Origin || tile == p.opts.LastMove.Dest)
+
+		draw.Draw(img, p.rect(l, tile), image.NewUniform(t.square(tile, moved)), image.Point{}, draw.Src)
+	}
+
+	for _, h := range p.opts.Highlights {
+		r := p.rect(l, h.Tile)
+
+		for y := r.Min.Y; y < r.Max.Y; y++ {
+			for x := r.Min.X; x < r.Max.X; x++ {
+				c.blend(x, y, colors[h.Color], 0.5)
+			}
+		}
+	}
+
+	if king := g.King[g.Turn]; p.opts.Check && king >= 0 && g.Checkers() != 0 {
+		p.check(c, p.rect(l, king), t.Check)
+	}
+
+	if p.opts.Coordinates {
+		p.coordinates(c, l, t)
+	}
+
+	for sq := 0; sq < 64; sq++ {
+		tile := chess.SquareTile(sq)
+
+		if piece := g.Position.Piece(tile); piece != nil {
+			r := p.rect(l, tile)
+			draw.Draw(img, r, p.piece(piece), image.Point{}, draw.Over)
+		}
+	}
+
+	for _, m := range p.opts.Markers {
+		x, y := l.center(m.Tile)
+		r := unit / 2.0 - 2.5
+
+		ring := []subpath{
+			circle(point{ x, y }, r + 1.75, 48),
+			circle(point{ x, y }, r - 1.75, 48),
+		}
+
+		c.fill(ring, true, colors[m.Color], 0.8)
+	}
+
+	for _, a := range p.opts.Arrows {
+		if a.Origin != a.Dest {
+			c.fill([]subpath{ { points: l.arrow(a), closed: true } }, false, colors[a.Color], 0.8)
+		}
+	}
+
+	return img
+}
+
+// rect of a tile in pixels
+func (p *painter) rect(l layout, tile int) image.Rectangle {
+	x, y := l.corner(tile)
+	x, y = x / unit * p.square, y / unit * p.square
+
+	return image.Rect(x, y, x + p.square, y + p.square)
+}
+
+// piece draws a piece on a transparent square the first time it's
+// needed.
+func (p *painter) piece(piece *chess.Piece) *image.RGBA {
+	if img, ok := p.pieces[*piece]; ok {
+		return img
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, p.square, p.square))
+	c := &canvas{ img: img, scale: float64(p.square) / unit }
+
+	c.art(pieceSVG(piece))
+	p.pieces[*piece] = img
+
+	return img
+}
+
+// check fades a color out from the middle of a square, like the
+// gradient of the SVG diagram.
+func (p *painter) check(c *canvas, r image.Rectangle, col color.RGBA) {
+	half := float64(p.square) / 2
+
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			d := math.Hypot(float64(x - r.Min.X) + 0.5 - half, float64(y - r.Min.Y) + 0.5 - half) / half
+
+			switch {
+				case d < 0.5:
+					c.blend(x, y, col, 1 - 0.6 * d)
+					break
+				case d < 1:
+					c.blend(x, y, col, 0.7 * (1 - d) / 0.5)
+					break
+			}
+		}
+	}
+}
+
+// coordinates are drawn in the corners of the edge squares, in the
+// color of the other squares.
+func (p *painter) coordinates(c *canvas, l layout, t *Theme) {
+	scale := max(1, p.square / 20)
+
+	for i := 0; i < 8; i++ {
+		bottom, left := l.tile(i, 7), l.tile(0, i)
+
+		r := p.rect(l, bottom)
+		p.glyph(c, byte('a' + chess.File(bottom)), r.Max.X - scale * 4, r.Max.Y - scale * 6, scale, t.opposite(bottom))
+
+		r = p.rect(l, left)
+		p.glyph(c, byte('1' + chess.Rank(left)), r.Min.X + scale, r.Min.Y + scale, scale, t.opposite(left))
+	}
+}
+
+// a tiny font of the coordinates, 3 pixels wide and 5 high
+var font = map[byte][5]string{
+	'a': { "...", ".##", "#.#", "#.#", ".##" },
+	'b': { "#..", "##.", "#.#", "#.#", "##." },
+	'c': { "...", ".##", "#..", "#..", ".##" },
+	'd': { "..#", ".##", "#.#", "#.#", ".##" },
+	'e': { "...", ".#.", "###", "#..", ".##" },
+	'f': { ".##", "#..", "###", "#..", "#.." },
+	'g': { ".##", "#.#", ".##", "..#", "##." },
+	'h': { "#..", "##.", "#.#", "#.#", "#.#" },
+	'1': { ".#.", "##.", ".#.", ".#.", "###" },
+	'2': { "##.", "..#", ".#.", "#..", "###" },
+	'3': { "##.", "..#", ".#.", "..#", "##." },
+	'4': { "#.#", "#.#", "###", "..#", "..#" },
+	'5': { "###", "#..", "##.", "..#", "##." },
+	'6': { ".##", "#..", "###", "#.#", "###" },
+	'7': { "###", "..#", ".#.", ".#.", ".#." },
+	'8': { "###", "#.#", "###", "#.#", "###" },
+}
+
+func (p *painter) glyph(c *canvas, ch byte, x, y, scale int, col color.RGBA) {
+	for row, bits := range font[ch] {
+		for i := range bits {
+			if bits[i] != '#' {
+				continue
+			}
+
+			r := image.Rect(x + i * scale, y + row * scale, x + (i + 1) * scale, y + (row + 1) * scale)
+			draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Src)
+		}
+	}
+}
+
+// palette of a game's animation: the colors of the board and pieces,
+// and steps between each pair of them for the edges of shapes.
+func (p *painter) palette() color.Palette {
+	t := theme(p.opts.Theme)
+
+	base := []color.RGBA{
+		t.Light, t.Dark, t.LightMoved, t.DarkMoved, t.Check,
+		{ 0, 0, 0, 255 },
+		{ 255, 255, 255, 255 },
+	}
+
+	base = append(base, colors[:]...)
+
+	var palette color.Palette
+
+	for _, c := range base {
+		palette = append(palette, c)
+	}
+
+	for i := range base {
+		for j := i + 1; j < len(base); j++ {
+			for _, f := range []float64{ 0.2, 0.4, 0.6, 0.8 } {
+				palette = append(palette, blend(base[i], base[j], f))
+			}
+		}
+	}
+
+	return palette
+}
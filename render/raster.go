@@ -0,0 +1,507 @@
+package render
+
+import (
+	"encoding/xml"
+	"image"
+	"image/color"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// samples taken along each side of a pixel for anti-aliasing
+const samples = 4
+
+// A canvas paints anti-aliased shapes on an image. Shapes are measured
+// in units, scaled to pixels and moved by an offset.
+type canvas struct {
+	img *image.RGBA
+	scale float64
+	offset point
+}
+
+// a subpath of a shape, closed when it ends with z
+type subpath struct {
+	points []point
+	closed bool
+}
+
+// an edge of a shape in pixels, going down
+type edge struct {
+	x0, y0, x1, y1 float64
+	dir int
+}
+
+// fill paints the inside of a shape, with a nonzero or even-odd rule.
+func (c *canvas) fill(shape []subpath, evenOdd bool, col color.RGBA, opacity float64) {
+	var edges []edge
+
+	top, bottom := math.Inf(1), math.Inf(-1)
+	left, right := math.Inf(1), math.Inf(-1)
+
+	for _, sp := range shape {
+		for i := range sp.points {
+			a, b := c.pixel(sp.points[i]), c.pixel(sp.points[(i + 1) % len(sp.points)])
+
+			top, bottom = math.Min(top, a.y), math.Max(bottom, a.y)
+			left, right = math.Min(left, a.x), math.Max(right, a.x)
+
+			switch {
+				case a.y < b.y:
+					edges = append(edges, edge{ a.x, a.y, b.x, b.y, 1 })
+					break
+				case a.y > b.y:
+					edges = append(edges, edge{ b.x, b.y, a.x, a.y, -1 })
+					break
+			}
+		}
+	}
+
+	bounds := c.img.Bounds()
+
+	x0, x1 := max(bounds.Min.X, int(math.Floor(left))), min(bounds.Max.X, int(math.Ceil(right)))
+	y0, y1 := max(bounds.Min.Y, int(math.Floor(top))), min(bounds.Max.Y, int(math.Ceil(bottom)))
+
+	if x0 >= x1 || y0 >= y1 {
+		return
+	}
+
+	type crossing struct {
+		x float64
+		dir int
+	}
+
+	coverage := make([]int, x1 - x0)
+	crossings := make([]crossing, 0, 16)
+
+	for y := y0; y < y1; y++ {
+		for i := range coverage {
+			coverage[i] = 0
+		}
+
+		for sy := 0; sy < samples; sy++ {
+			fy := float64(y) + (float64(sy) + 0.5) / samples
+
+			crossings = crossings[:0]
+
+			for _, e := range edges {
+				if fy >= e.y0 && fy < e.y1 {
+					crossings = append(crossings, crossing{ e.x0 + (fy - e.y0) * (e.x1 - e.x0) / (e.y1 - e.y0), e.dir })
+				}
+			}
+
+			sort.Slice(crossings, func(i, j int) bool {
+				return crossings[i].x < crossings[j].x
+			})
+
+			winding := 0
+
+			for i := 0; i + 1 < len(crossings); i++ {
+				winding += crossings[i].dir
+
+				if (evenOdd && winding % 2 != 0) || (evenOdd == false && winding != 0) {
+					span(coverage, crossings[i].x - float64(x0), crossings[i + 1].x - float64(x0))
+				}
+			}
+		}
+
+		for i, n := range coverage {
+			if n > 0 {
+				c.blend(x0 + i, y, col, opacity * float64(n) / (samples * samples))
+			}
+		}
+	}
+}
+
+// span counts the samples of a row between two crossings.
+func span(coverage []int, a, b float64) {
+	first := max(0, int(math.Ceil(a * samples - 0.5)))
+	last := min(len(coverage) * samples, int(math.Ceil(b * samples - 0.5)))
+
+	for s := first; s < last; s++ {
+		coverage[s / samples]++
+	}
+}
+
+// stroke paints the outline of a shape with round joins and caps.
+func (c *canvas) stroke(shape []subpath, width float64, col color.RGBA, opacity float64) {
+	c.fill(outline(shape, width / 2), false, col, opacity)
+}
+
+// blend a color over a pixel.
+func (c *canvas) blend(x, y int, col color.RGBA, alpha float64) {
+	i := c.img.PixOffset(x, y)
+	pix := c.img.Pix[i:i + 4]
+
+	for j, v := range [4]uint8{ col.R, col.G, col.B, 255 } {
+		pix[j] = uint8(float64(v) * alpha + float64(pix[j]) * (1 - alpha) + 0.5)
+	}
+}
+
+func (c *canvas) pixel(p point) point {
+	return point{ p.x * c.scale + c.offset.x, p.y * c.scale + c.offset.y }
+}
+
+// outline is the shape covered by lines along a shape: a rectangle
+// for every segment and a circle at every point, all wound the same
+// way so that they add up.
+func outline(shape []subpath, r float64) []subpath {
+	var polygons []subpath
+
+	for _, sp := range shape {
+		points := sp.points
+
+		if sp.closed && len(points) > 0 {
+			points = append(points[:len(points):len(points)], points[0])
+		}
+
+		for i := 0; i + 1 < len(points); i++ {
+			a, b := points[i], points[i + 1]
+			d := math.Hypot(b.x - a.x, b.y - a.y)
+
+			if d == 0 {
+				continue
+			}
+
+			nx, ny := (a.y - b.y) / d * r, (b.x - a.x) / d * r
+
+			polygons = append(polygons, wind(subpath{ points: []point{
+				{ a.x + nx, a.y + ny },
+				{ b.x + nx, b.y + ny },
+				{ b.x - nx, b.y - ny },
+				{ a.x - nx, a.y - ny },
+			}}))
+		}
+
+		for _, p := range points {
+			polygons = append(polygons, circle(p, r, 12))
+		}
+	}
+
+	return polygons
+}
+
+// circle is a polygon around a point.
+func circle(center point, r float64, sides int) subpath {
+	sp := subpath{ closed: true }
+
+	for i := 0; i < sides; i++ {
+		a := 2 * math.Pi * float64(i) / float64(sides)
+		sp.points = append(sp.points, point{ center.x + r * math.Cos(a), center.y + r * math.Sin(a) })
+	}
+
+	return sp
+}
+
+// wind turns a polygon clockwise (on screen), as circles are.
+func wind(sp subpath) subpath {
+	area := 0.0
+
+	for i, a := range sp.points {
+		b := sp.points[(i + 1) % len(sp.points)]
+		area += a.x * b.y - b.x * a.y
+	}
+
+	if area < 0 {
+		for i, j := 0, len(sp.points) - 1; i < j; i, j = i + 1, j - 1 {
+			sp.points[i], sp.points[j] = sp.points[j], sp.points[i]
+		}
+	}
+
+	return sp
+}
+
+// the style of SVG elements, inherited from their groups
+type style struct {
+	fill, stroke string
+	width float64
+	evenOdd bool
+}
+
+// art paints SVG artwork made of groups and paths, such as a piece.
+func (c *canvas) art(svg string) {
+	d := xml.NewDecoder(strings.NewReader("<g>" + svg + "</g>"))
+	stack := []style{ { fill: "#000", stroke: "none", width: 1 } }
+
+	for {
+		token, err := d.Token()
+
+		if err != nil {
+			break
+		}
+
+		switch t := token.(type) {
+			case xml.StartElement:
+				s := stack[len(stack) - 1]
+				path := ""
+
+				for _, a := range t.Attr {
+					switch a.Name.Local {
+						case "fill":
+							s.fill = a.Value
+							break
+						case "stroke":
+							s.stroke = a.Value
+							break
+						case "stroke-width":
+							s.width, _ = strconv.ParseFloat(a.Value, 64)
+							break
+						case "fill-rule":
+							s.evenOdd = a.Value == "evenodd"
+							break
+						case "d":
+							path = a.Value
+							break
+					}
+				}
+
+				stack = append(stack, s)
+
+				if t.Name.Local != "path" {
+					break
+				}
+
+				shape := parsePath(path)
+
+				if s.fill != "none" {
+					c.fill(shape, s.evenOdd, parseColor(s.fill), 1)
+				}
+
+				if s.stroke != "none" && s.width > 0 {
+					c.stroke(shape, s.width, parseColor(s.stroke), 1)
+				}
+				break
+			case xml.EndElement:
+				stack = stack[:len(stack) - 1]
+				break
+		}
+	}
+}
+
+// parseColor reads #rgb or #rrggbb.
+func parseColor(s string) color.RGBA {
+	s = strings.TrimPrefix(s, "#")
+
+	if len(s) == 3 {
+		s = string([]byte{ s[0], s[0], s[1], s[1], s[2], s[2] })
+	}
+
+	n, _ := strconv.ParseUint(s, 16, 32)
+
+	return color.RGBA{ uint8(n >> 16), uint8(n >> 8), uint8(n), 255 }
+}
+
+// commands and numbers of SVG path data
+var rePathToken = regexp.MustCompile(`[A-Za-z]|[-+]?(?:\d*\.\d+|\d+\.?)(?:[eE][-+]?\d+)?`)
+
+// parsePath reads SVG path data into subpaths of straight lines, with
+// curves and arcs flattened.
+func parsePath(d string) []subpath {
+	tokens := rePathToken.FindAllString(d, -1)
+
+	var shape []subpath
+	var cur, start, control point
+	var cmd byte
+	var smooth bool
+
+	i := 0
+
+	number := func() float64 {
+		if i >= len(tokens) {
+			return 0
+		}
+
+		f, _ := strconv.ParseFloat(tokens[i], 64)
+		i++
+		return f
+	}
+
+	// a pair of coordinates, relative to the current point in lowercase
+	coords := func() point {
+		p := point{ number(), number() }
+
+		if cmd >= 'a' {
+			p.x, p.y = p.x + cur.x, p.y + cur.y
+		}
+
+		return p
+	}
+
+	add := func(p point) {
+		if len(shape) == 0 || shape[len(shape) - 1].closed {
+			shape = append(shape, subpath{ points: []point{ cur } })
+		}
+
+		shape[len(shape) - 1].points = append(shape[len(shape) - 1].points, p)
+		cur = p
+	}
+
+	for i < len(tokens) {
+		if c := tokens[i][0]; c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' {
+			cmd = c
+			i++
+		} else if cmd == 0 || cmd == 'z' || cmd == 'Z' {
+			break
+		}
+
+		curve := cmd == 'C' || cmd == 'c' || cmd == 'S' || cmd == 's'
+
+		switch cmd {
+			case 'M', 'm':
+				cur = coords()
+				start = cur
+				shape = append(shape, subpath{ points: []point{ cur } })
+
+				// more coordinates are lines
+				cmd -= 'M' - 'L'
+				break
+			case 'L', 'l':
+				add(coords())
+				break
+			case 'H', 'h':
+				x := number()
+
+				if cmd == 'h' {
+					x += cur.x
+				}
+
+				add(point{ x, cur.y })
+				break
+			case 'V', 'v':
+				y := number()
+
+				if cmd == 'v' {
+					y += cur.y
+				}
+
+				add(point{ cur.x, y })
+				break
+			case 'C', 'c', 'S', 's':
+				c1 := cur
+
+				if cmd == 'C' || cmd == 'c' {
+					c1 = coords()
+				} else if smooth {
+					c1 = point{ 2 * cur.x - control.x, 2 * cur.y - control.y }
+				}
+
+				c2 := coords()
+				p := coords()
+
+				for _, q := range cubic(cur, c1, c2, p) {
+					add(q)
+				}
+
+				control = c2
+				break
+			case 'A', 'a':
+				rx, ry, rotation := number(), number(), number()
+				large, sweep := number() != 0, number() != 0
+				p := coords()
+
+				for _, q := range arc(cur, p, rx, ry, rotation, large, sweep) {
+					add(q)
+				}
+				break
+			case 'Z', 'z':
+				if len(shape) > 0 {
+					shape[len(shape) - 1].closed = true
+				}
+
+				cur = start
+				break
+			default:
+				return shape
+		}
+
+		// a smooth curve only mirrors the control point of a curve
+		smooth = curve
+	}
+
+	return shape
+}
+
+// cubic flattens a bezier curve, without its first point.
+func cubic(p0, p1, p2, p3 point) []point {
+	const steps = 12
+
+	points := make([]point, steps)
+
+	for i := 1; i <= steps; i++ {
+		t := float64(i) / steps
+		u := 1 - t
+
+		a, b, c, d := u * u * u, 3 * u * u * t, 3 * u * t * t, t * t * t
+
+		points[i - 1] = point{
+			a * p0.x + b * p1.x + c * p2.x + d * p3.x,
+			a * p0.y + b * p1.y + c * p2.y + d * p3.y,
+		}
+	}
+
+	return points
+}
+
+// arc flattens an elliptical arc between two points, without the first,
+// following the SVG implementation notes.
+func arc(p0, p1 point, rx, ry, rotation float64, large, sweep bool) []point {
+	if rx == 0 || ry == 0 {
+		return []point{ p1 }
+	}
+
+	rx, ry = math.Abs(rx), math.Abs(ry)
+
+	sin, cos := math.Sincos(rotation * math.Pi / 180)
+
+	// the start point in the ellipse's frame, from the middle of the chord
+	dx, dy := (p0.x - p1.x) / 2, (p0.y - p1.y) / 2
+	x, y := cos * dx + sin * dy, -sin * dx + cos * dy
+
+	// radii too small to reach are scaled up
+	if l := x * x / (rx * rx) + y * y / (ry * ry); l > 1 {
+		rx, ry = rx * math.Sqrt(l), ry * math.Sqrt(l)
+	}
+
+	num := rx * rx * ry * ry - rx * rx * y * y - ry * ry * x * x
+	den := rx * rx * y * y + ry * ry * x * x
+	k := math.Sqrt(math.Max(0, num / den))
+
+	if large == sweep {
+		k = -k
+	}
+
+	cx, cy := k * rx * y / ry, -k * ry * x / rx
+
+	// the center back in the picture
+	mx, my := cos * cx - sin * cy + (p0.x + p1.x) / 2, sin * cx + cos * cy + (p0.y + p1.y) / 2
+
+	theta := math.Atan2((y - cy) / ry, (x - cx) / rx)
+	delta := math.Atan2((-y - cy) / ry, (-x - cx) / rx) - theta
+
+	switch {
+		case sweep && delta < 0:
+			delta += 2 * math.Pi
+			break
+		case sweep == false && delta > 0:
+			delta -= 2 * math.Pi
+			break
+	}
+
+	const steps = 16
+
+	points := make([]point, steps)
+
+	for i := 1; i <= steps; i++ {
+		a := theta + delta * float64(i) / steps
+		ex, ey := rx * math.Cos(a), ry * math.Sin(a)
+
+		points[i - 1] = point{ mx + cos * ex - sin * ey, my + sin * ex + cos * ey }
+	}
+
+	// land exactly on the end
+	points[steps - 1] = p1
+
+	return points
+}
@@ -0,0 +1,246 @@
+package render
+
+import "../chess"
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// A Color of an arrow or marked square, the four of the [%cal] and
+// [%csl] commands in PGN comments.
+type Color int
+
+const (
+	Green Color = iota
+	Red
+	Yellow
+	Blue
+)
+
+// An Arrow is drawn from one tile to another.
+type Arrow struct {
+	Origin, Dest int
+	Color Color
+}
+
+// A Marker highlights a tile.
+type Marker struct {
+	Tile int
+	Color Color
+}
+
+// A Theme is the colors of a board.
+type Theme struct {
+	Light, Dark color.RGBA
+	LightMoved, DarkMoved color.RGBA   // squares of the last move
+	Check color.RGBA
+}
+
+var (
+	BrownTheme = &Theme{
+		Light: color.RGBA{ 240, 217, 181, 255 },
+		Dark: color.RGBA{ 181, 136, 99, 255 },
+		LightMoved: color.RGBA{ 205, 210, 106, 255 },
+		DarkMoved: color.RGBA{ 170, 162, 58, 255 },
+		Check: color.RGBA{ 230, 65, 65, 255 },
+	}
+
+	BlueTheme = &Theme{
+		Light: color.RGBA{ 222, 227, 230, 255 },
+		Dark: color.RGBA{ 140, 162, 173, 255 },
+		LightMoved: color.RGBA{ 195, 216, 135, 255 },
+		DarkMoved: color.RGBA{ 151, 172, 89, 255 },
+		Check: color.RGBA{ 230, 65, 65, 255 },
+	}
+
+	GreenTheme = &Theme{
+		Light: color.RGBA{ 238, 238, 210, 255 },
+		Dark: color.RGBA{ 118, 150, 86, 255 },
+		LightMoved: color.RGBA{ 246, 246, 105, 255 },
+		DarkMoved: color.RGBA{ 186, 202, 43, 255 },
+		Check: color.RGBA{ 230, 65, 65, 255 },
+	}
+)
+
+// the theme to draw with, brown unless another is chosen
+func theme(t *Theme) *Theme {
+	if t == nil {
+		return BrownTheme
+	}
+	return t
+}
+
+// square is the color of a tile, or of a square of the last move.
+func (t *Theme) square(tile int, moved bool) color.RGBA {
+	dark := (chess.Rank(tile) + chess.File(tile)) % 2 == 0
+
+	switch {
+		case moved && dark:
+			return t.DarkMoved
+		case moved:
+			return t.LightMoved
+		case dark:
+			return t.Dark
+	}
+
+	return t.Light
+}
+
+var colors = [4]color.RGBA{
+	Green: { 21, 120, 27, 255 },
+	Red: { 136, 32, 32, 255 },
+	Yellow: { 230, 143, 0, 255 },
+	Blue: { 0, 48, 136, 255 },
+}
+
+// blend mixes a color into another by a fraction
+func blend(c, x color.RGBA, f float64) color.RGBA {
+	mix := func(a, b uint8) uint8 {
+		return uint8(float64(a) * (1 - f) + float64(b) * f + 0.5)
+	}
+
+	return color.RGBA{ mix(c.R, x.R), mix(c.G, x.G), mix(c.B, x.B), 255 }
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+var colorLetters = map[byte]Color{ 'G': Green, 'R': Red, 'Y': Yellow, 'B': Blue }
+
+// ParseArrow reads an arrow as in a [%cal] command, a color letter and
+// two squares (Ge2e4). Without the letter it's green.
+func ParseArrow(s string) (Arrow, bool) {
+	c, s := colorLetter(s)
+
+	if len(s) != 4 {
+		return Arrow{}, false
+	}
+
+	origin, dest := chess.ParseTile(s[:2]), chess.ParseTile(s[2:])
+
+	return Arrow{ Origin: origin, Dest: dest, Color: c }, origin >= 0 && dest >= 0
+}
+
+// ParseMarker reads a marked square as in a [%csl] command (Rd5).
+func ParseMarker(s string) (Marker, bool) {
+	c, s := colorLetter(s)
+	tile := chess.ParseTile(s)
+
+	return Marker{ Tile: tile, Color: c }, tile >= 0
+}
+
+// the color letter leading an arrow or marker
+func colorLetter(s string) (Color, string) {
+	if len(s) > 0 {
+		if c, ok := colorLetters[s[0]]; ok {
+			return c, s[1:]
+		}
+	}
+	return Green, s
+}
+
+// [%cal Gc2c4,Re8e1] and [%csl Rd5] commands in a comment
+var reAnnotation = regexp.MustCompile("\\[%(cal|csl)\\s+([^\\]]*)\\]")
+
+// Annotations reads the arrows and circled squares drawn on the board
+// by the [%cal] and [%csl] commands of a PGN comment.
+func Annotations(comment string) ([]Arrow, []Marker) {
+	var arrows []Arrow
+	var markers []Marker
+
+	for _, cmd := range reAnnotation.FindAllStringSubmatch(comment, -1) {
+		for _, s := range strings.Split(cmd[2], ",") {
+			s = strings.TrimSpace(s)
+
+			if cmd[1] == "cal" {
+				if a, ok := ParseArrow(s); ok {
+					arrows = append(arrows, a)
+				}
+			} else {
+				if m, ok := ParseMarker(s); ok {
+					markers = append(markers, m)
+				}
+			}
+		}
+	}
+
+	return arrows, markers
+}
+
+// squares are drawn 45 units wide, the size of the piece artwork
+const unit = 45
+
+// a point on the board in units
+type point struct {
+	x, y float64
+}
+
+// layout places the squares of a board in units from the top left,
+// with white or black at the bottom.
+type layout struct {
+	flip bool
+}
+
+// tile drawn in a column and row from the top left
+func (l layout) tile(x, y int) int {
+	if l.flip {
+		return chess.Tile(y, 7 - x)
+	}
+	return chess.Tile(7 - y, x)
+}
+
+// corner is the top left of a tile as it's drawn.
+func (l layout) corner(tile int) (int, int) {
+	x, y := chess.File(tile), 7 - chess.Rank(tile)
+
+	if l.flip {
+		x, y = 7 - x, 7 - y
+	}
+
+	return x * unit, y * unit
+}
+
+func (l layout) center(tile int) (float64, float64) {
+	x, y := l.corner(tile)
+
+	return float64(x) + unit / 2.0, float64(y) + unit / 2.0
+}
+
+// arrow is the outline of an arrow from the middle of one square to
+// near the middle of another.
+func (l layout) arrow(a Arrow) []point {
+	const (
+		shaft = 4.5           // half the width of the line
+		head = 11.0           // half the width of the head
+		length = 18.0         // of the head
+	)
+
+	x0, y0 := l.center(a.Origin)
+	x1, y1 := l.center(a.Dest)
+
+	dx, dy := x1 - x0, y1 - y0
+	d := math.Hypot(dx, dy)
+
+	// along the arrow and across it
+	ux, uy := dx / d, dy / d
+	nx, ny := -uy, ux
+
+	// the head ends a little before the middle
+	x1, y1 = x1 - ux * 6, y1 - uy * 6
+	bx, by := x1 - ux * length, y1 - uy * length
+
+	return []point{
+		{ x0 + nx * shaft, y0 + ny * shaft },
+		{ bx + nx * shaft, by + ny * shaft },
+		{ bx + nx * head, by + ny * head },
+		{ x1, y1 },
+		{ bx - nx * head, by - ny * head },
+		{ bx - nx * shaft, by - ny * shaft },
+		{ x0 - nx * shaft, y0 - ny * shaft },
+	}
+}
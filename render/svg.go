@@ -0,0 +1,174 @@
+package render
+
+import "../chess"
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+)
+
+// SVGOptions change how a board is drawn as an SVG diagram.
+type SVGOptions struct {
+	Size int                  // width and height in pixels
+	Flip bool                 // black at the bottom
+	Theme *Theme              // colors of the squares, brown if nil
+	Coordinates bool          // ranks and files on the edge squares
+	Check bool                // highlight a king in check
+	LastMove *chess.Move      // highlight the squares it moved between
+	Highlights []Marker       // squares filled with a color
+	Arrows []Arrow
+	Markers []Marker          // squares circled, as with [%csl]
+}
+
+var DefaultSVG = SVGOptions{
+	Size: 360,
+	Coordinates: true,
+	Check: true,
+}
+
+// SVG draws the position as a standalone SVG document, everything it
+// needs is embedded. The same position and options always draw the
+// same document.
+func SVG(g *chess.Game, opts SVGOptions) string {
+	var b strings.Builder
+
+	size := opts.Size
+
+	if size <= 0 {
+		size = DefaultSVG.Size
+	}
+
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" version="1.1" width="%d" height="%d" viewBox="0 0 %d %d">`, size, size, 8 * unit, 8 * unit)
+	b.WriteString("\n")
+
+	opts.defs(&b, g)
+	opts.board(&b, g)
+
+	for _, h := range opts.Highlights {
+		x, y := opts.layout().corner(h.Tile)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" opacity="0.5"/>`+"\n", x, y, unit, unit, hex(colors[h.Color]))
+	}
+
+	if king := g.King[g.Turn]; opts.Check && king >= 0 && g.Checkers() != 0 {
+		x, y := opts.layout().corner(king)
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="%d" height="%d" fill="url(#check)"/>`+"\n", x, y, unit, unit)
+	}
+
+	if opts.Coordinates {
+		opts.coordinates(&b)
+	}
+
+	// pieces, in the order of the squares
+	for sq := 0; sq < 64; sq++ {
+		tile := chess.SquareTile(sq)
+
+		if p := g.Position.Piece(tile); p != nil {
+			x, y := opts.layout().corner(tile)
+			fmt.Fprintf(&b, `<use xlink:href="#%s" x="%d" y="%d"/>`+"\n", pieceID(p), x, y)
+		}
+	}
+
+	for _, m := range opts.Markers {
+		x, y := opts.layout().center(m.Tile)
+		fmt.Fprintf(&b, `<circle cx="%g" cy="%g" r="%g" fill="none" stroke="%s" stroke-width="3.5" opacity="0.8"/>`+"\n", x, y, unit / 2.0 - 2.5, hex(colors[m.Color]))
+	}
+
+	for _, a := range opts.Arrows {
+		if a.Origin != a.Dest {
+			points := opts.layout().arrow(a)
+			s := make([]string, len(points))
+
+			for i, p := range points {
+				s[i] = fmt.Sprintf("%.1f,%.1f", p.x, p.y)
+			}
+
+			fmt.Fprintf(&b, `<polygon points="%s" fill="%s" opacity="0.8"/>`+"\n", strings.Join(s, " "), hex(colors[a.Color]))
+		}
+	}
+
+	b.WriteString("</svg>\n")
+
+	return b.String()
+}
+
+// defs are the pieces on the board and the check gradient
+func (opts *SVGOptions) defs(b *strings.Builder, g *chess.Game) {
+	b.WriteString("<defs>\n")
+
+	for _, c := range [2]chess.Color{ chess.White, chess.Black } {
+		for kind := chess.Pawn; kind <= chess.Queen; kind++ {
+			if g.Bits.Pieces[c][kind] != 0 {
+				p := &chess.Piece{ Color: c, Kind: kind }
+				fmt.Fprintf(b, `<g id="%s">%s</g>`+"\n", pieceID(p), pieceSVG(p))
+			}
+		}
+	}
+
+	check := hex(theme(opts.Theme).Check)
+
+	b.WriteString(`<radialGradient id="check">`)
+	fmt.Fprintf(b, `<stop offset="0%%" stop-color="%s"/>`, check)
+	fmt.Fprintf(b, `<stop offset="50%%" stop-color="%s" stop-opacity="0.7"/>`, check)
+	fmt.Fprintf(b, `<stop offset="100%%" stop-color="%s" stop-opacity="0"/>`, check)
+	b.WriteString("</radialGradient>\n")
+	b.WriteString("</defs>\n")
+}
+
+// board draws the squares, with the last move's in other colors
+func (opts *SVGOptions) board(b *strings.Builder, g *chess.Game) {
+	t := theme(opts.Theme)
+
+	fmt.Fprintf(b, `<rect x="0" y="0" width="%d" height="%d" fill="%s"/>`+"\n", 8 * unit, 8 * unit, hex(t.Light))
+
+	for sq := 0; sq < 64; sq++ {
+		tile := chess.SquareTile(sq)
+		moved := opts.LastMove != nil && (tile == opts.LastMove.Origin || tile == opts.LastMove.Dest)
+
+		if fill := t.square(tile, moved); fill != t.Light {
+			x, y := opts.layout().corner(tile)
+			fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, unit, unit, hex(fill))
+		}
+	}
+}
+
+// coordinates are written in the corners of the edge squares, in the
+// color of the other squares.
+func (opts *SVGOptions) coordinates(b *strings.Builder) {
+	t := theme(opts.Theme)
+
+	b.WriteString(`<g font-family="sans-serif" font-size="9" font-weight="bold">` + "\n")
+
+	// files along the bottom, ranks down the left
+	for i := 0; i < 8; i++ {
+		bottom, left := opts.layout().tile(i, 7), opts.layout().tile(0, i)
+
+		x, y := opts.layout().corner(bottom)
+		fmt.Fprintf(b, `<text x="%d" y="%d" text-anchor="end" fill="%s">%c</text>`+"\n", x + unit - 2, y + unit - 2, hex(t.opposite(bottom)), 'a' + chess.File(bottom))
+
+		x, y = opts.layout().corner(left)
+		fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s">%d</text>`+"\n", x + 2, y + 10, hex(t.opposite(left)), chess.Rank(left) + 1)
+	}
+
+	b.WriteString("</g>\n")
+}
+
+// the color of the squares other than a tile's
+func (t *Theme) opposite(tile int) color.RGBA {
+	if t.square(tile, false) == t.Dark {
+		return t.Light
+	}
+	return t.Dark
+}
+
+func (opts *SVGOptions) layout() layout {
+	return layout{ flip: opts.Flip }
+}
+
+// the id of a piece's artwork, e.g. wN or bp
+func pieceID(p *chess.Piece) string {
+	if p.Color == chess.White {
+		return "w" + string(p.Rune())
+	}
+	return "b" + string(p.Rune())
+}
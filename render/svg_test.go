@@ -0,0 +1,95 @@
+package render_test
+
+import (
+	"../chess"
+	"../fen"
+	"../render"
+)
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares a diagram with testdata/name.svg, or rewrites it
+// with -update.
+func golden(t *testing.T, name, svg string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name + ".svg")
+
+	if *update {
+		if err := os.WriteFile(path, []byte(svg), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+
+	if err != nil {
+		t.Fatalf("%s, run go test -update to create it", err)
+	}
+
+	if svg != string(want) {
+		t.Errorf("%s differs from the diagram drawn, run go test -update if it's intended", path)
+	}
+}
+
+// play the moves from the start position, returning the last
+func play(t *testing.T, moves ...string) (*chess.Game, *chess.Move) {
+	t.Helper()
+
+	g := chess.NewGame()
+
+	var last *chess.Move
+
+	for _, s := range moves {
+		if last = g.ParseUCI(s); last == nil {
+			t.Fatalf("illegal move %s", s)
+		}
+
+		g.PerformMove(last)
+	}
+
+	return g, last
+}
+
+func TestSVGStart(t *testing.T) {
+	golden(t, "start", render.SVG(chess.NewGame(), render.DefaultSVG))
+}
+
+func TestSVGFlipped(t *testing.T) {
+	opts := render.DefaultSVG
+	opts.Flip = true
+
+	g := fen.Parse("r1bqkb1r/pppp1ppp/2n2n2/4p3/2B1P3/5N2/PPPP1PPP/RNBQK2R w KQkq - 4 4")
+
+	golden(t, "flipped", render.SVG(g, opts))
+}
+
+func TestSVGCheck(t *testing.T) {
+	g, last := play(t, "e2e4", "f7f5", "d1h5")
+
+	opts := render.DefaultSVG
+	opts.LastMove = last
+
+	golden(t, "check", render.SVG(g, opts))
+}
+
+func TestSVGAnnotations(t *testing.T) {
+	g, _ := play(t, "e2e4", "e7e5", "g1f3", "b8c6")
+
+	opts := render.DefaultSVG
+	opts.Arrows, opts.Markers = render.Annotations("[%cal Gf3e5,Rc6d4,Yf1b5] [%csl Re5,Bd4]")
+
+	if len(opts.Arrows) != 3 || len(opts.Markers) != 2 {
+		t.Fatalf("%d arrows and %d markers, want 3 and 2", len(opts.Arrows), len(opts.Markers))
+	}
+
+	golden(t, "annotations", render.SVG(g, opts))
+}
@@ -0,0 +1,318 @@
+package render
+
+import "../chess"
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+type Style int
+
+const (
+	Bordered Style = iota     // a grid around every square
+	Compact                   // a character per square
+)
+
+// TextOptions change how a board is drawn as text.
+type TextOptions struct {
+	Style Style
+	Unicode bool              // figurines and box drawing
+	ANSI bool                 // colored squares for terminals
+	Theme *Theme              // colors of the squares, brown if nil
+	Flip bool                 // black at the bottom
+	Coordinates bool          // ranks and files around the board
+	Check bool                // highlight a king in check
+	LastMove *chess.Move      // highlight the squares it moved between
+	Arrows []Arrow
+	Markers []Marker
+}
+
+var DefaultText = TextOptions{
+	Coordinates: true,
+	Check: true,
+}
+
+var figurines = [2][6]rune{
+	{ '♙', '♗', '♘', '♖', '♔', '♕' },
+	{ '♟', '♝', '♞', '♜', '♚', '♛' },
+}
+
+// highlights of a square, stronger last
+const (
+	plain = iota
+	moved
+	pointed
+	marked
+	check
+)
+
+// what is drawn on a square
+type square struct {
+	piece *chess.Piece
+	path rune                 // part of an arrow over the square
+	pathColor Color
+	highlight int
+	color Color               // of a marker or arrow head
+}
+
+// Text writes the position as text. Without ANSI colors, highlights
+// surround the piece in a bordered square: (moved), [marked or pointed
+// at by an arrow] and *check*. A compact board has no room around the
+// piece, so the closing mark takes the place of the space after it,
+// e.g. P) or k*. Arrows are drawn over empty squares along straight
+// lines.
+func Text(w io.Writer, g *chess.Game, opts TextOptions) error {
+	squares := opts.squares(g)
+
+	ranks := []int{ 7, 6, 5, 4, 3, 2, 1, 0 }
+	files := []int{ 0, 1, 2, 3, 4, 5, 6, 7 }
+
+	if opts.Flip {
+		ranks, files = files, ranks
+	}
+
+	var b strings.Builder
+
+	margin := ""
+
+	if opts.Coordinates {
+		margin = "  "
+	}
+
+	if opts.Style == Bordered {
+		b.WriteString(margin + opts.rule(0) + "\n")
+	}
+
+	for i, rank := range ranks {
+		if opts.Coordinates {
+			fmt.Fprintf(&b, "%d ", rank + 1)
+		}
+
+		if opts.Style == Bordered {
+			b.WriteString(opts.vertical())
+		}
+
+		for j, file := range files {
+			s := &squares[rank << 3 | file]
+			b.WriteString(opts.cell(s, chess.Tile(rank, file)))
+
+			switch {
+				case opts.Style == Bordered:
+					b.WriteString(opts.vertical())
+					break
+				case opts.ANSI == false && j < 7 && s.highlight == plain:
+					b.WriteString(" ")
+					break
+			}
+		}
+
+		b.WriteString("\n")
+
+		if opts.Style == Bordered {
+			if i < 7 {
+				b.WriteString(margin + opts.rule(1) + "\n")
+			} else {
+				b.WriteString(margin + opts.rule(2) + "\n")
+			}
+		}
+	}
+
+	if opts.Coordinates {
+		labels := make([]string, 8)
+
+		for i, file := range files {
+			labels[i] = string(rune('a' + file))
+		}
+
+		switch {
+			case opts.Style == Bordered:
+				b.WriteString("    " + strings.Join(labels, "   ") + "\n")
+				break
+			case opts.ANSI:
+				b.WriteString("   " + strings.Join(labels, "  ") + "\n")
+				break
+			default:
+				b.WriteString("  " + strings.Join(labels, " ") + "\n")
+				break
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// squares of the board by rank and file, with their highlights
+func (opts *TextOptions) squares(g *chess.Game) [64]square {
+	var squares [64]square
+
+	for sq := range squares {
+		squares[sq].piece = g.Position.Piece(chess.SquareTile(sq))
+	}
+
+	highlight := func(tile, h int, c Color) {
+		if s := &squares[chess.Square(tile)]; h > s.highlight {
+			s.highlight, s.color = h, c
+		}
+	}
+
+	if m := opts.LastMove; m != nil {
+		highlight(m.Origin, moved, 0)
+		highlight(m.Dest, moved, 0)
+	}
+
+	for _, a := range opts.Arrows {
+		dr, df := sign(chess.Rank(a.Dest) - chess.Rank(a.Origin)), sign(chess.File(a.Dest) - chess.File(a.Origin))
+
+		// only straight arrows cross squares on the way
+		if straight(a.Origin, a.Dest) {
+			glyph := opts.direction(dr, df)
+
+			for tile := a.Origin + dr << 4 + df; ; tile += dr << 4 + df {
+				squares[chess.Square(tile)].path = glyph
+				squares[chess.Square(tile)].pathColor = a.Color
+
+				if tile == a.Dest {
+					break
+				}
+			}
+		}
+
+		highlight(a.Dest, pointed, a.Color)
+	}
+
+	for _, m := range opts.Markers {
+		highlight(m.Tile, marked, m.Color)
+	}
+
+	if opts.Check && g.Checkers() != 0 {
+		highlight(g.King[g.Turn], check, 0)
+	}
+
+	return squares
+}
+
+func straight(origin, dest int) bool {
+	dr, df := chess.Rank(dest) - chess.Rank(origin), chess.File(dest) - chess.File(origin)
+
+	return origin != dest && (dr == 0 || df == 0 || dr == df || dr == -df)
+}
+
+func sign(n int) int {
+	switch {
+		case n > 0: return 1
+		case n < 0: return -1
+	}
+	return 0
+}
+
+// direction of an arrow as it looks on the board
+func (opts *TextOptions) direction(dr, df int) rune {
+	if opts.Flip {
+		dr, df = -dr, -df
+	}
+
+	// indexed by rank then file step, up is a higher rank
+	arrows := [3][3]rune{ { '↙', '↓', '↘' }, { '←', ' ', '→' }, { '↖', '↑', '↗' } }
+	lines := [3][3]rune{ { '/', '|', '\\' }, { '-', ' ', '-' }, { '\\', '|', '/' } }
+
+	if opts.Unicode {
+		return arrows[dr + 1][df + 1]
+	}
+	return lines[dr + 1][df + 1]
+}
+
+// cell draws a square, padded to the width of the style.
+func (opts *TextOptions) cell(s *square, tile int) string {
+	ch := opts.glyph(s)
+
+	if opts.ANSI == false {
+		if opts.Style == Compact {
+			return string(ch) + [...]string{ "", ")", "]", "]", "*" }[s.highlight]
+		}
+
+		left, right := " ", " "
+
+		switch s.highlight {
+			case moved:
+				left, right = "(", ")"
+				break
+			case pointed, marked:
+				left, right = "[", "]"
+				break
+			case check:
+				left, right = "*", "*"
+				break
+		}
+
+		return left + string(ch) + right
+	}
+
+	t := theme(opts.Theme)
+	bg := t.square(tile, s.highlight == moved)
+
+	switch s.highlight {
+		case pointed, marked:
+			bg = blend(bg, colors[s.color], 0.6)
+			break
+		case check:
+			bg = t.Check
+			break
+	}
+
+	fg := color.RGBA{ 0, 0, 0, 255 }
+
+	switch {
+		case s.piece != nil && s.piece.Color == chess.White:
+			fg = color.RGBA{ 255, 255, 255, 255 }
+			break
+		case s.piece == nil && s.path != 0:
+			fg = colors[s.pathColor]
+			break
+	}
+
+	return fmt.Sprintf("\x1b[48;2;%d;%d;%dm\x1b[38;2;%d;%d;%dm %c \x1b[0m", bg.R, bg.G, bg.B, fg.R, fg.G, fg.B, ch)
+}
+
+// glyph of the piece on a square, or an arrow passing over it
+func (opts *TextOptions) glyph(s *square) rune {
+	switch {
+		case s.piece != nil && opts.Unicode && opts.ANSI:
+			// solid figurines show colors best
+			return figurines[chess.Black][s.piece.Kind]
+		case s.piece != nil && opts.Unicode:
+			return figurines[s.piece.Color][s.piece.Kind]
+		case s.piece != nil:
+			return s.piece.Rune()
+		case s.path != 0:
+			return s.path
+		case opts.Style == Compact && opts.ANSI == false && opts.Unicode:
+			return '·'
+		case opts.Style == Compact && opts.ANSI == false:
+			return '.'
+	}
+
+	return ' '
+}
+
+// rule is a line between ranks: the top, middle or bottom.
+func (opts *TextOptions) rule(n int) string {
+	if opts.Unicode == false {
+		return "+" + strings.Repeat("---+", 8)
+	}
+
+	corners := [3][3]string{ { "┌", "┬", "┐" }, { "├", "┼", "┤" }, { "└", "┴", "┘" } }
+	c := corners[n]
+
+	return c[0] + strings.Repeat("───" + c[1], 7) + "───" + c[2]
+}
+
+func (opts *TextOptions) vertical() string {
+	if opts.Unicode {
+		return "│"
+	}
+	return "|"
+}
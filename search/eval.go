@@ -0,0 +1,112 @@
+package search
+
+import "../chess"
+
+// piece-square tables from white's point of view, a1 first
+var pieceSquares = [6][64]int{
+	// pawn
+	{
+		 0,  0,  0,  0,  0,  0,  0,  0,
+		 5, 10, 10,-20,-20, 10, 10,  5,
+		 5, -5,-10,  0,  0,-10, -5,  5,
+		 0,  0,  0, 20, 20,  0,  0,  0,
+		 5,  5, 10, 25, 25, 10,  5,  5,
+		10, 10, 20, 30, 30, 20, 10, 10,
+		50, 50, 50, 50, 50, 50, 50, 50,
+		 0,  0,  0,  0,  0,  0,  0,  0,
+	},
+
+	// bishop
+	{
+		-20,-10,-10,-10,-10,-10,-10,-20,
+		-10,  5,  0,  0,  0,  0,  5,-10,
+		-10, 10, 10, 10, 10, 10, 10,-10,
+		-10,  0, 10, 10, 10, 10,  0,-10,
+		-10,  5,  5, 10, 10,  5,  5,-10,
+		-10,  0,  5, 10, 10,  5,  0,-10,
+		-10,  0,  0,  0,  0,  0,  0,-10,
+		-20,-10,-10,-10,-10,-10,-10,-20,
+	},
+
+	// knight
+	{
+		-50,-40,-30,-30,-30,-30,-40,-50,
+		-40,-20,  0,  5,  5,  0,-20,-40,
+		-30,  5, 10, 15, 15, 10,  5,-30,
+		-30,  0, 15, 20, 20, 15,  0,-30,
+		-30,  5, 15, 20, 20, 15,  5,-30,
+		-30,  0, 10, 15, 15, 10,  0,-30,
+		-40,-20,  0,  0,  0,  0,-20,-40,
+		-50,-40,-30,-30,-30,-30,-40,-50,
+	},
+
+	// rook
+	{
+		 0,  0,  0,  5,  5,  0,  0,  0,
+		-5,  0,  0,  0,  0,  0,  0, -5,
+		-5,  0,  0,  0,  0,  0,  0, -5,
+		-5,  0,  0,  0,  0,  0,  0, -5,
+		-5,  0,  0,  0,  0,  0,  0, -5,
+		-5,  0,  0,  0,  0,  0,  0, -5,
+		 5, 10, 10, 10, 10, 10, 10,  5,
+		 0,  0,  0,  0,  0,  0,  0,  0,
+	},
+
+	// king
+	{
+		 20, 30, 10,  0,  0, 10, 30, 20,
+		 20, 20,  0,  0,  0,  0, 20, 20,
+		-10,-20,-20,-20,-20,-20,-20,-10,
+		-20,-30,-30,-40,-40,-30,-30,-20,
+		-30,-40,-40,-50,-50,-40,-40,-30,
+		-30,-40,-40,-50,-50,-40,-40,-30,
+		-30,-40,-40,-50,-50,-40,-40,-30,
+		-30,-40,-40,-50,-50,-40,-40,-30,
+	},
+
+	// queen
+	{
+		-20,-10,-10, -5, -5,-10,-10,-20,
+		-10,  0,  5,  0,  0,  0,  0,-10,
+		-10,  5,  5,  5,  5,  5,  0,-10,
+		  0,  0,  5,  5,  5,  5,  0, -5,
+		 -5,  0,  5,  5,  5,  5,  0, -5,
+		-10,  0,  5,  5,  5,  5,  0,-10,
+		-10,  0,  0,  0,  0,  0,  0,-10,
+		-20,-10,-10, -5, -5,-10,-10,-20,
+	},
+}
+
+// Evaluate scores a position in centipawns from the point of view of
+// the player to move, using material and piece-square tables.
+func Evaluate(g *chess.Game) int {
+	score := 0
+
+	for c := chess.White; c <= chess.Black; c++ {
+		sign := 1
+
+		if c != g.Turn {
+			sign = -1
+		}
+
+		for kind := chess.Pawn; kind <= chess.Queen; kind++ {
+			for pieces := g.Bits.Pieces[c][kind]; pieces != 0; {
+				sq := pieces.Pop()
+
+				// the tables are mirrored for black
+				if c == chess.Black {
+					sq ^= 56
+				}
+
+				// kings are never traded, so only position counts
+				if kind != chess.King {
+					score += sign * chess.PieceValue[kind]
+				}
+
+				score += sign * pieceSquares[kind][sq]
+			}
+		}
+	}
+
+	return score
+}
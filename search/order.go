@@ -0,0 +1,90 @@
+package search
+
+import "../chess"
+
+// move ordering scores, best first
+const (
+	orderPV = 1 << 30
+	orderHash = 1 << 29
+	orderCapture = 1 << 24
+	orderKiller = 1 << 20
+)
+
+// order sorts the moves so that the most promising are searched first:
+// the previous principal variation, the best move from the table,
+// captures by most valuable victim and least valuable attacker, killer
+// moves and then by history.
+func (w *worker) order(g *chess.Game, list *chess.MoveList, ply int, hashMove uint16) {
+	var scores [chess.MaxMoves]int
+
+	pv := w.followsPV(ply)
+
+	for i := 0; i < list.Len; i++ {
+		if pv && SameMove(&list.Moves[i], &w.prevPV[ply]) {
+			scores[i] = orderPV
+		} else if hashMove != 0 && PackMove(&list.Moves[i]) == hashMove {
+			scores[i] = orderHash
+		} else {
+			scores[i] = w.score(g, &list.Moves[i], ply)
+		}
+	}
+
+	// insertion sort is quick for short lists
+	for i := 1; i < list.Len; i++ {
+		move, score := list.Moves[i], scores[i]
+		j := i
+
+		for ; j > 0 && scores[j - 1] < score; j-- {
+			list.Moves[j], scores[j] = list.Moves[j - 1], scores[j - 1]
+		}
+
+		list.Moves[j], scores[j] = move, score
+	}
+}
+
+func (w *worker) score(g *chess.Game, move *chess.Move, ply int) int {
+	if move.Capture || move.Promote {
+		victim := chess.Pawn
+		attacker := chess.Pawn
+
+		if p := g.Position.Piece(move.Dest); p != nil {
+			victim = p.Kind
+		}
+
+		if p := g.Position.Piece(move.Origin); p != nil {
+			attacker = p.Kind
+		}
+
+		score := orderCapture + chess.PieceValue[victim] * 10 - chess.PieceValue[attacker] / 10
+
+		if move.Promote {
+			score += chess.PieceValue[move.Kind]
+		}
+
+		return score
+	}
+
+	for i, killer := range w.killers[ply] {
+		if SameMove(move, &killer) {
+			return orderKiller - i
+		}
+	}
+
+	return w.history[g.Turn][chess.Square(move.Origin)][chess.Square(move.Dest)]
+}
+
+// followsPV is true when the moves played so far in the search are
+// the start of the previous principal variation.
+func (w *worker) followsPV(ply int) bool {
+	if ply >= len(w.prevPV) {
+		return false
+	}
+
+	for i := 0; i < ply; i++ {
+		if SameMove(&w.line[i], &w.prevPV[i]) == false {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,598 @@
+package search
+
+import "../chess"
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	Infinity = 32000
+	Mate = 31000
+	MaxPly = 100
+)
+
+// Limits bound how long a search runs. Zero values mean no limit. The
+// time on the clock is that of the player to move, and the search
+// divides it up between the moves left (see TimeLimits).
+type Limits struct {
+	Depth int                 // maximum depth in plies
+	Nodes int                 // maximum number of nodes visited
+	MoveTime time.Duration    // exact time to search
+	Time time.Duration        // time left on the clock
+	Increment time.Duration   // added to the clock after each move
+	MovesToGo int             // moves until the next time control
+	SearchMoves []chess.Move  // only search these root moves
+	MultiPV int               // number of best lines to find
+	History []uint64          // hashes of the game's positions before this one
+	Stop <-chan bool          // closing it stops the search
+}
+
+// Result is the outcome of the last completed iteration.
+type Result struct {
+	Move chess.Move           // best move found
+	Score int                 // score in centipawns for the player to move
+	Depth int                 // depth of the search
+	Nodes int                 // number of nodes visited
+	PV []chess.Move           // principal variation, starting with Move
+	Lines []Line              // best lines with MultiPV, best first
+}
+
+// A Line is one of the principal variations of a MultiPV search.
+type Line struct {
+	Move chess.Move           // first move of the line
+	Score int                 // score for the player to move
+	Depth int                 // depth the line was searched to
+	PV []chess.Move           // moves of the line
+	SAN []string              // moves of the line in SAN
+}
+
+// A Searcher runs iterative deepening alpha-beta searches. With more
+// than one thread it uses Lazy SMP: every thread searches the same
+// position on its own copy of the game, and they share what they
+// learn through the transposition table. A Searcher can be reused,
+// but only runs one search at a time.
+type Searcher struct {
+	Table *Table              // transposition table, may be shared
+	Threads int               // number of goroutines searching
+	Info func(Result)         // called after each iteration, if set
+
+	limits Limits
+	timer *timer
+	workers []*worker
+
+	// shared between the threads
+	nodes int64
+	stopped int32
+}
+
+// A worker is one thread of the search, with its own move lists and
+// move ordering.
+type worker struct {
+	s *Searcher
+	id int
+	result Result
+
+	// nodes not yet added to the searcher's count
+	nodes int
+
+	// one move list per ply and the triangular pv table
+	lists [MaxPly + 1]chess.MoveList
+	pv [MaxPly + 1][MaxPly + 1]chess.Move
+	pvLen [MaxPly + 1]int
+
+	// moves played to reach each ply, the hashes of the positions
+	// along the way and the last iteration's pv
+	line [MaxPly + 1]chess.Move
+	keys [MaxPly + 1]uint64
+	prevPV []chess.Move
+
+	// root moves already searched by other lines of this iteration
+	exclude []chess.Move
+
+	// quiet move ordering
+	killers [MaxPly + 1][2]chess.Move
+	history [2][64][64]int
+}
+
+func New() *Searcher {
+	return &Searcher{
+		Table: NewTable(DefaultHashMB),
+		Threads: 1,
+	}
+}
+
+// IsMate reports whether a score is a forced mate for either side.
+func IsMate(score int) bool {
+	return score > Mate - MaxPly || score < MaxPly - Mate
+}
+
+// MateMoves returns the number of moves until mate, which is negative
+// when the player to move is being mated, or 0 for other scores.
+func MateMoves(score int) int {
+	switch {
+		case score > Mate - MaxPly:  return (Mate - score + 1) / 2
+		case score < MaxPly - Mate:  return -(Mate + score) / 2
+	}
+
+	return 0
+}
+
+// SameMove compares two moves, ignoring annotations such as check.
+func SameMove(a, b *chess.Move) bool {
+	if a.Origin != b.Origin || a.Dest != b.Dest || a.Promote != b.Promote {
+		return false
+	}
+
+	return a.Promote == false || a.Kind == b.Kind
+}
+
+// Search runs iterative deepening on a position until a limit is
+// reached, and returns the result of the deepest completed iteration.
+// A single thread always searches the same way, so its results are
+// repeatable.
+func (s *Searcher) Search(g *chess.Game, limits Limits) Result {
+	threads := s.Threads
+
+	if threads < 1 {
+		threads = 1
+	}
+
+	s.limits = limits
+	s.timer = newTimer(g, limits)
+	s.nodes = 0
+	s.stopped = 0
+	s.Table.NewSearch()
+
+	// workers are kept between searches, they're big
+	for len(s.workers) < threads {
+		s.workers = append(s.workers, &worker{s: s, id: len(s.workers)})
+	}
+
+	var wg sync.WaitGroup
+
+	// helper threads search until the main thread is done
+	for _, w := range s.workers[1:threads] {
+		wg.Add(1)
+
+		go func(w *worker, g chess.Game) {
+			defer wg.Done()
+			w.search(&g)
+		}(w, *g)
+	}
+
+	main := s.workers[0]
+	main.search(g)
+
+	s.Stop()
+	wg.Wait()
+
+	// report the deepest result, preferring the main thread
+	result := main.result
+
+	for _, w := range s.workers[1:threads] {
+		if w.result.Depth > result.Depth && len(w.result.PV) > 0 {
+			result = w.result
+		}
+	}
+
+	result.Nodes = int(atomic.LoadInt64(&s.nodes))
+	result.annotate(g)
+
+	return result
+}
+
+// annotate writes each line in SAN.
+func (r *Result) annotate(g *chess.Game) {
+	for i := range r.Lines {
+		line := &r.Lines[i]
+		pos := *g
+
+		line.SAN = make([]string, len(line.PV))
+
+		for j := range line.PV {
+			line.SAN[j] = pos.SAN(&line.PV[j])
+			pos.PerformMove(&line.PV[j])
+		}
+	}
+}
+
+// Stop ends a search early. It's safe to call from another goroutine.
+func (s *Searcher) Stop() {
+	atomic.StoreInt32(&s.stopped, 1)
+}
+
+func (s *Searcher) isStopped() bool {
+	return atomic.LoadInt32(&s.stopped) != 0
+}
+
+func (w *worker) search(g *chess.Game) {
+	w.result = Result{}
+	w.nodes = 0
+	w.killers = [MaxPly + 1][2]chess.Move{}
+	w.history = [2][64][64]int{}
+	w.prevPV = nil
+
+	depth := w.s.limits.Depth
+
+	if depth <= 0 || depth > MaxPly {
+		depth = MaxPly
+	}
+
+	// odd helpers skip ahead a ply, so the threads don't all search
+	// the same tree at the same time
+	start := 1 + w.id & 1
+
+	if start > depth {
+		start = depth
+	}
+
+	multi := w.s.limits.MultiPV
+
+	if multi < 1 {
+		multi = 1
+	}
+
+	for d := start; d <= depth; d++ {
+		lines := make([]Line, 0, multi)
+		w.exclude = w.exclude[:0]
+
+		// score of a position without any moves
+		none := 0
+
+		// search again without the moves of the better lines
+		for len(lines) < multi {
+			score := w.negamax(g, d, 0, -Infinity, Infinity)
+
+			if w.stopped() || w.pvLen[0] == 0 {
+				none = score
+				break
+			}
+
+			lines = append(lines, Line{
+				Move: w.pv[0][0],
+				Score: score,
+				Depth: d,
+				PV: append([]chess.Move(nil), w.pv[0][:w.pvLen[0]]...),
+			})
+
+			w.exclude = append(w.exclude, w.pv[0][0])
+		}
+
+		// an unfinished iteration can't be trusted, unless the main
+		// thread has nothing else to report
+		if w.stopped() && (w.result.Depth > 0 || w.id > 0) {
+			break
+		}
+
+		// a game that's over has no lines
+		if len(lines) == 0 {
+			if w.stopped() == false {
+				w.result.Score = none
+				w.result.Depth = d
+			}
+			break
+		}
+
+		sort.SliceStable(lines, func(i, j int) bool {
+			return lines[i].Score > lines[j].Score
+		})
+
+		w.result = Result{
+			Move: lines[0].Move,
+			Score: lines[0].Score,
+			Depth: d,
+			PV: lines[0].PV,
+			Lines: lines,
+		}
+
+		w.prevPV = w.result.PV
+
+		if w.id == 0 && w.s.Info != nil {
+			info := w.result
+			info.Nodes = int(atomic.LoadInt64(&w.s.nodes)) + w.nodes
+			info.annotate(g)
+
+			w.s.Info(info)
+		}
+
+		// no reason to search deeper than a forced mate
+		if w.stopped() || IsMate(lines[0].Score) {
+			break
+		}
+
+		// the main thread decides when there isn't time for another
+		if w.id == 0 && w.s.timer.iteration(&w.result) {
+			w.s.Stop()
+			break
+		}
+	}
+
+	w.flush()
+}
+
+func (w *worker) negamax(g *chess.Game, depth, ply, alpha, beta int) int {
+	w.pvLen[ply] = 0
+
+	if w.stopped() {
+		return 0
+	}
+
+	key := g.Hash()
+	w.keys[ply] = key
+
+	// fifty move rule, repetitions and dead positions
+	if ply > 0 && (g.HalfMove >= 100 || w.repeated(g, ply) || g.InsufficientMaterial()) {
+		return 0
+	}
+
+	check := inCheck(g)
+
+	// search longer when in check
+	if check {
+		depth++
+	}
+
+	if depth <= 0 || ply >= MaxPly {
+		return w.quiesce(g, ply, alpha, beta)
+	}
+
+	w.nodes++
+
+	root := ply == 0 && (len(w.s.limits.SearchMoves) > 0 || len(w.exclude) > 0)
+
+	// use what was learned about the position before
+	var hashMove uint16
+
+	if e, ok := w.s.Table.Probe(key); ok && root == false {
+		hashMove = e.Move
+
+		if score := scoreFromTable(e.Score, ply); ply > 0 && e.Depth >= depth {
+			switch {
+				case e.Bound == Exact:                    return score
+				case e.Bound == Lower && score >= beta:   return score
+				case e.Bound == Upper && score <= alpha:  return score
+			}
+		}
+	}
+
+	list := &w.lists[ply]
+	g.GenerateMoves(list)
+
+	if root {
+		list.Filter(func(move *chess.Move) bool {
+			for i := range w.exclude {
+				if SameMove(move, &w.exclude[i]) {
+					return false
+				}
+			}
+
+			if len(w.s.limits.SearchMoves) == 0 {
+				return true
+			}
+
+			for i := range w.s.limits.SearchMoves {
+				if SameMove(move, &w.s.limits.SearchMoves[i]) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if list.Len == 0 {
+		return w.outcome(g, ply)
+	}
+
+	w.order(g, list, ply, hashMove)
+
+	bound := Upper
+	best := uint16(0)
+
+	for i := 0; i < list.Len; i++ {
+		move := &list.Moves[i]
+
+		child := *g
+		child.PerformMove(move)
+		w.line[ply] = *move
+
+		score := -w.negamax(&child, depth - 1, ply + 1, -beta, -alpha)
+
+		if w.s.isStopped() {
+			return 0
+		}
+
+		if score > alpha {
+			alpha = score
+			bound = Exact
+			best = PackMove(move)
+
+			// extend the principal variation with the child's
+			w.pv[ply][0] = *move
+			copy(w.pv[ply][1:], w.pv[ply + 1][:w.pvLen[ply + 1]])
+			w.pvLen[ply] = w.pvLen[ply + 1] + 1
+
+			if alpha >= beta {
+				if move.Capture == false && move.Promote == false {
+					w.refute(g, move, ply, depth)
+				}
+
+				bound = Lower
+				break
+			}
+		}
+	}
+
+	// a restricted root search isn't the whole position
+	if root == false {
+		w.s.Table.Store(key, best, scoreToTable(alpha, ply), depth, bound)
+	}
+
+	return alpha
+}
+
+// quiesce only searches captures until the position is quiet, so
+// that the evaluation isn't taken in the middle of an exchange.
+func (w *worker) quiesce(g *chess.Game, ply, alpha, beta int) int {
+	w.pvLen[ply] = 0
+	w.nodes++
+
+	// captures can leave too little material to mate
+	if g.InsufficientMaterial() {
+		return 0
+	}
+
+	check := inCheck(g)
+	list := &w.lists[ply]
+
+	// all moves are searched to escape check
+	if check {
+		g.GenerateMoves(list)
+	} else {
+		g.GenerateStage(list, chess.Captures)
+	}
+
+	// without captures most positions are simply quiet, only mate and
+	// the ends of variants are scored here. Stalemates are left to the
+	// main search, generating every move to find them is too slow.
+	if list.Len == 0 && (check || g.VariantOutcome() != chess.InProgress) {
+		return w.outcome(g, ply)
+	}
+
+	if check == false {
+		stand := Evaluate(g)
+
+		if stand >= beta || ply >= MaxPly {
+			return stand
+		}
+
+		if stand > alpha {
+			alpha = stand
+		}
+	}
+
+	w.order(g, list, ply, 0)
+
+	for i := 0; i < list.Len; i++ {
+		move := &list.Moves[i]
+
+		// skip captures losing material
+		if check == false && g.SEEGreaterEqual(move, 0) == false {
+			continue
+		}
+
+		child := *g
+		child.PerformMove(move)
+		w.line[ply] = *move
+
+		score := -w.quiesce(&child, ply + 1, -beta, -alpha)
+
+		if w.s.isStopped() {
+			return 0
+		}
+
+		if score > alpha {
+			alpha = score
+
+			w.pv[ply][0] = *move
+			copy(w.pv[ply][1:], w.pv[ply + 1][:w.pvLen[ply + 1]])
+			w.pvLen[ply] = w.pvLen[ply + 1] + 1
+
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+
+	return alpha
+}
+
+// outcome scores a position without any legal moves, with quicker
+// mates scoring higher.
+func (w *worker) outcome(g *chess.Game, ply int) int {
+	switch g.Outcome() {
+		case chess.Win(g.Turn):             return Mate - ply
+		case chess.Win(g.Turn.Opponent()):  return ply - Mate
+	}
+
+	return 0
+}
+
+// repeated is true when the position at a ply was reached before, in
+// the line searched or the game before it. Only positions since the
+// last capture or pawn move can repeat, and a position repeated even
+// once is scored as a draw, since the players could repeat it again.
+func (w *worker) repeated(g *chess.Game, ply int) bool {
+	history := w.s.limits.History
+
+	for back := 4; back <= g.HalfMove; back += 2 {
+		i := ply - back
+
+		switch {
+			case i >= 0:
+				if w.keys[i] == w.keys[ply] {
+					return true
+				}
+				break
+			case len(history) + i >= 0:
+				if history[len(history) + i] == w.keys[ply] {
+					return true
+				}
+				break
+			default:
+				return false
+		}
+	}
+
+	return false
+}
+
+// stopped checks the limits of the search, counting the nodes of the
+// worker every so often.
+func (w *worker) stopped() bool {
+	if w.nodes >= 1024 {
+		w.flush()
+	}
+
+	return w.s.isStopped()
+}
+
+func (w *worker) flush() {
+	nodes := atomic.AddInt64(&w.s.nodes, int64(w.nodes))
+	w.nodes = 0
+
+	if limit := w.s.limits.Nodes; limit > 0 && nodes >= int64(limit) {
+		w.s.Stop()
+	}
+
+	if w.s.timer.expired() {
+		w.s.Stop()
+	}
+
+	select {
+		case <-w.s.limits.Stop:
+			w.s.Stop()
+			break
+		default:
+			break
+	}
+}
+
+// refute remembers a quiet move that caused a beta cutoff.
+func (w *worker) refute(g *chess.Game, move *chess.Move, ply, depth int) {
+	if SameMove(move, &w.killers[ply][0]) == false {
+		w.killers[ply][1] = w.killers[ply][0]
+		w.killers[ply][0] = *move
+	}
+
+	w.history[g.Turn][chess.Square(move.Origin)][chess.Square(move.Dest)] += depth * depth
+}
+
+func inCheck(g *chess.Game) bool {
+	king := g.King[g.Turn]
+
+	return king >= 0 && g.InCheck(king)
+}
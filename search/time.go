@@ -0,0 +1,120 @@
+package search
+
+import "../chess"
+
+import "time"
+
+const (
+	// MoveOverhead is kept back from every move for the time it takes
+	// the move to reach the GUI.
+	MoveOverhead = 20 * time.Millisecond
+
+	// moves the clock is expected to last when there's no control to
+	// reach, as in sudden death
+	movesLeft = 30
+)
+
+// TimeLimits decides how long to think about a move. The soft limit is
+// the time aimed for: no new iteration starts after it, though it's
+// stretched while the search is unsure. The hard limit stops the search
+// wherever it is. Both are zero for a search without a clock.
+func (l Limits) TimeLimits() (time.Duration, time.Duration) {
+	if l.MoveTime > 0 {
+		return l.MoveTime, l.MoveTime
+	}
+
+	if l.Time <= 0 {
+		return 0, 0
+	}
+
+	available := l.Time - MoveOverhead
+	moves := l.MovesToGo
+
+	if moves <= 0 || moves > movesLeft {
+		moves = movesLeft
+	}
+
+	soft := available / time.Duration(moves) + l.Increment * 3 / 4
+	hard := soft * 5
+
+	// never risk the whole clock, even for the last move of a control
+	if most := available * 4 / 5; hard > most {
+		hard = most
+	}
+
+	if soft > hard {
+		soft = hard
+	}
+
+	if hard < time.Millisecond {
+		soft, hard = time.Millisecond, time.Millisecond
+	}
+
+	return soft, hard
+}
+
+// a timer keeps a search to its time limits
+type timer struct {
+	start time.Time
+	soft, hard time.Duration
+
+	// a forced move only needs a move to play
+	forced bool
+
+	// how often the best move has changed lately, and the last result
+	instability float64
+	last Result
+}
+
+func newTimer(g *chess.Game, limits Limits) *timer {
+	t := &timer{ start: time.Now() }
+	t.soft, t.hard = limits.TimeLimits()
+
+	// only playing on a clock is it worth saving the time
+	if limits.Time > 0 && limits.MoveTime <= 0 {
+		t.forced = len(g.CollectMoves()) == 1
+	}
+
+	return t
+}
+
+// expired is true once the hard limit has passed.
+func (t *timer) expired() bool {
+	return t.hard > 0 && time.Since(t.start) >= t.hard
+}
+
+// iteration is true when the search should stop after completing an
+// iteration. The soft limit grows by half for each recent change of
+// the best move, and by up to half again as the score drops.
+func (t *timer) iteration(r *Result) bool {
+	if t.hard == 0 {
+		return false
+	}
+
+	if t.forced {
+		return true
+	}
+
+	scale := 1.0
+
+	if t.last.Depth > 0 {
+		t.instability /= 2
+
+		if SameMove(&r.Move, &t.last.Move) == false {
+			t.instability++
+		}
+
+		if drop := t.last.Score - r.Score; drop > 0 && IsMate(r.Score) == false {
+			if drop > 100 {
+				drop = 100
+			}
+
+			scale += float64(drop) / 200
+		}
+	}
+
+	scale += t.instability / 2
+	t.last = *r
+
+	return time.Since(t.start) >= time.Duration(float64(t.soft) * scale)
+}
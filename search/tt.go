@@ -0,0 +1,246 @@
+package search
+
+import "../chess"
+
+import "sync/atomic"
+
+// the bound a stored score is
+type Bound uint8
+
+const (
+	NoBound Bound = iota
+	Upper                     // failed low, the score is at most this
+	Lower                     // failed high, the score is at least this
+	Exact                     // principal variation score
+)
+
+const DefaultHashMB = 16
+
+// An Entry is what the table remembers about a position.
+type Entry struct {
+	Move uint16               // best move, packed with PackMove
+	Score int                 // score relative to the position
+	Depth int                 // depth of the search
+	Bound Bound               // what kind of score it is
+	Age uint8                 // search that stored the entry
+}
+
+// Each entry is two words: the key xor the data, and the data. If two
+// threads write an entry at the same time, the key no longer verifies
+// and the entry is ignored, so no locks are needed.
+type slot struct {
+	check uint64
+	data uint64
+}
+
+// The first slot of a bucket keeps the deepest (or newest) search,
+// the second is always replaced.
+type bucket [2]slot
+
+// A Table is a transposition table shared by searches. It is safe to
+// use from multiple goroutines at once.
+type Table struct {
+	buckets []bucket
+	mask uint64
+	age uint32
+
+	// statistics
+	probes, hits, stores uint64
+}
+
+// TableStats are the numbers reported about a table.
+type TableStats struct {
+	Probes, Hits, Stores uint64
+	HitRate float64           // hits per probe
+	Hashfull int              // permille of the table used this search
+}
+
+// NewTable allocates a table of (at most) the given size in MB.
+func NewTable(mb int) *Table {
+	t := new(Table)
+	t.Resize(mb)
+
+	return t
+}
+
+// Resize reallocates the table, losing every entry. The number of
+// buckets is rounded down to a power of 2.
+func (t *Table) Resize(mb int) {
+	if mb < 1 {
+		mb = 1
+	}
+
+	n := uint64(mb) << 20 / 32
+
+	// round down to a power of 2
+	for n & (n - 1) != 0 {
+		n &= n - 1
+	}
+
+	t.buckets = make([]bucket, n)
+	t.mask = n - 1
+	t.Clear()
+}
+
+func (t *Table) Clear() {
+	for i := range t.buckets {
+		t.buckets[i] = bucket{}
+	}
+
+	atomic.StoreUint32(&t.age, 0)
+	atomic.StoreUint64(&t.probes, 0)
+	atomic.StoreUint64(&t.hits, 0)
+	atomic.StoreUint64(&t.stores, 0)
+}
+
+// NewSearch ages the table, so that entries from previous searches
+// are replaced first.
+func (t *Table) NewSearch() {
+	atomic.AddUint32(&t.age, 1)
+}
+
+// data is packed as: move (16 bits), score (16), depth (8), bound (8)
+// and age (8).
+func pack(e Entry) uint64 {
+	return uint64(e.Move) |
+		uint64(uint16(int16(e.Score))) << 16 |
+		uint64(uint8(int8(e.Depth))) << 32 |
+		uint64(e.Bound) << 40 |
+		uint64(e.Age) << 48
+}
+
+func unpack(data uint64) Entry {
+	return Entry{
+		Move: uint16(data),
+		Score: int(int16(data >> 16)),
+		Depth: int(int8(data >> 32)),
+		Bound: Bound(data >> 40),
+		Age: uint8(data >> 48),
+	}
+}
+
+func (t *Table) bucket(key uint64) *bucket {
+	return &t.buckets[key & t.mask]
+}
+
+// Probe looks up a position by its hash key.
+func (t *Table) Probe(key uint64) (Entry, bool) {
+	b := t.bucket(key)
+
+	atomic.AddUint64(&t.probes, 1)
+
+	for i := range b {
+		data := atomic.LoadUint64(&b[i].data)
+		check := atomic.LoadUint64(&b[i].check)
+
+		if check ^ data == key && data != 0 {
+			atomic.AddUint64(&t.hits, 1)
+			return unpack(data), true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// Store saves what was learned about a position.
+func (t *Table) Store(key uint64, move uint16, score, depth int, bound Bound) {
+	b := t.bucket(key)
+	age := uint8(atomic.LoadUint32(&t.age))
+
+	e := Entry{
+		Move: move,
+		Score: score,
+		Depth: depth,
+		Bound: bound,
+		Age: age,
+	}
+
+	// replace the deep slot if it's the same position, shallower, or old
+	s := &b[1]
+	data := atomic.LoadUint64(&b[0].data)
+
+	if data == 0 || atomic.LoadUint64(&b[0].check) ^ data == key {
+		s = &b[0]
+
+		// keep the move if there's no new one
+		if old := unpack(data); move == 0 && data != 0 {
+			e.Move = old.Move
+		}
+	} else if old := unpack(data); old.Age != age || old.Depth <= depth {
+		s = &b[0]
+	}
+
+	data = pack(e)
+
+	atomic.StoreUint64(&s.data, data)
+	atomic.StoreUint64(&s.check, key ^ data)
+	atomic.AddUint64(&t.stores, 1)
+}
+
+// Hashfull is the permille of the first thousand entries that were
+// written by the current search, as reported by UCI.
+func (t *Table) Hashfull() int {
+	age := uint8(atomic.LoadUint32(&t.age))
+	n, used := 0, 0
+
+	for i := 0; i < len(t.buckets) && n < 1000; i++ {
+		for j := range t.buckets[i] {
+			if data := atomic.LoadUint64(&t.buckets[i][j].data); data != 0 && unpack(data).Age == age {
+				used++
+			}
+			n++
+		}
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	return used * 1000 / n
+}
+
+func (t *Table) Stats() TableStats {
+	stats := TableStats{
+		Probes: atomic.LoadUint64(&t.probes),
+		Hits: atomic.LoadUint64(&t.hits),
+		Stores: atomic.LoadUint64(&t.stores),
+		Hashfull: t.Hashfull(),
+	}
+
+	if stats.Probes > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(stats.Probes)
+	}
+
+	return stats
+}
+
+// PackMove squeezes a move into 16 bits: the origin and destination
+// squares and any promotion.
+func PackMove(move *chess.Move) uint16 {
+	m := uint16(chess.Square(move.Origin)) | uint16(chess.Square(move.Dest)) << 6
+
+	if move.Promote {
+		m |= uint16(move.Kind + 1) << 12
+	}
+
+	return m
+}
+
+// scores of mates are stored relative to the position, not the root
+func scoreToTable(score, ply int) int {
+	switch {
+		case score > Mate - MaxPly:  return score + ply
+		case score < MaxPly - Mate:  return score - ply
+	}
+
+	return score
+}
+
+func scoreFromTable(score, ply int) int {
+	switch {
+		case score > Mate - MaxPly:  return score - ply
+		case score < MaxPly - Mate:  return score + ply
+	}
+
+	return score
+}
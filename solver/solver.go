@@ -0,0 +1,317 @@
+package solver
+
+import "../chess"
+
+// A Node is a move in a solution tree. The replies to a move by the
+// attacking side are every defense, and the replies to a defense are
+// every continuation that still works (so duals show up as more than
+// one reply).
+type Node struct {
+	Move chess.Move           // move played
+	SAN string                // move in standard algebraic notation
+	Mate bool                 // the move ends the problem
+	Replies []*Node           // moves answering this one
+}
+
+// A Solution is the result of solving a problem. When Keys is empty
+// there is no solution. More than one key is a cook. A refutation
+// without a move is a stalemate.
+type Solution struct {
+	Keys []*Node              // first moves that solve the problem
+	Tries []*Node             // first moves that fail, with a refutation
+}
+
+// MateIn finds every key move for the player to move that forces mate
+// in at most n moves, with the full tree of defenses and mates. Each
+// move that fails is a try, replied to by a defense that refutes it.
+func MateIn(g *chess.Game, n int) *Solution {
+	us := g.Turn
+	solution := new(Solution)
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		node := &Node{
+			Move: *move,
+			SAN: g.SAN(move),
+			Mate: child.Outcome() == chess.Win(us),
+		}
+
+		if node.Mate {
+			solution.Keys = append(solution.Keys, node)
+			continue
+		}
+
+		if move, ok := escape(&child, n - 1, us); ok {
+			node.Replies = []*Node{ refutation(&child, move) }
+			solution.Tries = append(solution.Tries, node)
+			continue
+		}
+
+		node.Replies = defenses(&child, n - 1, us)
+		solution.Keys = append(solution.Keys, node)
+	}
+
+	return solution
+}
+
+// attack is true when the attacker to move can force mate in n.
+func attack(g *chess.Game, n int, us chess.Color) bool {
+	if n <= 0 {
+		return false
+	}
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		if child.Outcome() == chess.Win(us) {
+			return true
+		}
+
+		if n > 1 {
+			if _, ok := escape(&child, n - 1, us); ok == false {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// escape returns a defense that escapes mate in n and true, or false
+// if every defense fails. A stalemate escapes without a move.
+func escape(g *chess.Game, n int, us chess.Color) (*chess.Move, bool) {
+	moves := g.CollectMoves()
+
+	if len(moves) == 0 {
+		return nil, g.Outcome() != chess.Win(us)
+	}
+
+	// out of time to mate
+	if n <= 0 {
+		return moves[0], true
+	}
+
+	for _, move := range moves {
+		child := *g
+		child.PerformMove(move)
+
+		if attack(&child, n, us) == false {
+			return move, true
+		}
+	}
+
+	return nil, false
+}
+
+// refutation is the node for a defense that escapes.
+func refutation(g *chess.Game, move *chess.Move) *Node {
+	if move == nil {
+		return &Node{}
+	}
+
+	return &Node{ Move: *move, SAN: g.SAN(move) }
+}
+
+// defenses builds the tree of every defense against a key and every
+// continuation mating in time after it.
+func defenses(g *chess.Game, n int, us chess.Color) []*Node {
+	nodes := make([]*Node, 0, 8)
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		defense := &Node{ Move: *move, SAN: g.SAN(move) }
+
+		for _, cont := range child.CollectMoves() {
+			next := child
+			next.PerformMove(cont)
+
+			node := &Node{
+				Move: *cont,
+				SAN: child.SAN(cont),
+				Mate: next.Outcome() == chess.Win(us),
+			}
+
+			if node.Mate {
+				defense.Replies = append(defense.Replies, node)
+				continue
+			}
+
+			if n <= 1 {
+				continue
+			}
+
+			if _, ok := escape(&next, n - 1, us); ok == false {
+				node.Replies = defenses(&next, n - 1, us)
+				defense.Replies = append(defense.Replies, node)
+			}
+		}
+
+		nodes = append(nodes, defense)
+	}
+
+	return nodes
+}
+
+// HelpmateIn finds every line where the player to move and the
+// opponent cooperate so that the opponent mates in n moves. Both sides
+// play n moves, the player to move first.
+func HelpmateIn(g *chess.Game, n int) *Solution {
+	solution := new(Solution)
+	solution.Keys = help(g, n, g.Turn.Opponent())
+
+	return solution
+}
+
+func help(g *chess.Game, n int, mater chess.Color) []*Node {
+	nodes := make([]*Node, 0)
+
+	if n <= 0 {
+		return nodes
+	}
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		// the side being mated can't end the game itself
+		if child.Outcome() != chess.InProgress {
+			continue
+		}
+
+		node := &Node{ Move: *move, SAN: g.SAN(move) }
+
+		for _, cont := range child.CollectMoves() {
+			next := child
+			next.PerformMove(cont)
+
+			reply := &Node{ Move: *cont, SAN: child.SAN(cont) }
+
+			if n == 1 {
+				if next.Outcome() == chess.Win(mater) {
+					reply.Mate = true
+					node.Replies = append(node.Replies, reply)
+				}
+				continue
+			}
+
+			// the mate has to come on the last move
+			if next.Outcome() != chess.InProgress {
+				continue
+			}
+
+			if reply.Replies = help(&next, n - 1, mater); len(reply.Replies) > 0 {
+				node.Replies = append(node.Replies, reply)
+			}
+		}
+
+		if len(node.Replies) > 0 {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// SelfmateIn finds every key for the player to move that forces the
+// opponent to give mate in at most n moves, against any defense.
+func SelfmateIn(g *chess.Game, n int) *Solution {
+	us := g.Turn
+	solution := new(Solution)
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		node := &Node{ Move: *move, SAN: g.SAN(move) }
+
+		if move, ok := selfEscape(&child, n, us); ok {
+			node.Replies = []*Node{ refutation(&child, move) }
+			solution.Tries = append(solution.Tries, node)
+			continue
+		}
+
+		node.Replies = selfDefenses(&child, n, us)
+		solution.Keys = append(solution.Keys, node)
+	}
+
+	return solution
+}
+
+// selfAttack is true when the player to move can force the opponent
+// to mate them within n moves.
+func selfAttack(g *chess.Game, n int, us chess.Color) bool {
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		if _, ok := selfEscape(&child, n, us); ok == false {
+			return true
+		}
+	}
+
+	return false
+}
+
+// selfEscape returns a move by the opponent that avoids mating within
+// n moves and true, or false if every move mates or leads to a forced
+// selfmate. Having no moves at all escapes.
+func selfEscape(g *chess.Game, n int, us chess.Color) (*chess.Move, bool) {
+	moves := g.CollectMoves()
+
+	if len(moves) == 0 {
+		return nil, true
+	}
+
+	for _, move := range moves {
+		child := *g
+		child.PerformMove(move)
+
+		if child.Outcome() == chess.Win(us.Opponent()) {
+			continue
+		}
+
+		if n <= 1 || child.Outcome() != chess.InProgress || selfAttack(&child, n - 1, us) == false {
+			return move, true
+		}
+	}
+
+	return nil, false
+}
+
+// selfDefenses builds the tree of every defense against a selfmate key.
+func selfDefenses(g *chess.Game, n int, us chess.Color) []*Node {
+	nodes := make([]*Node, 0, 8)
+
+	for _, move := range g.CollectMoves() {
+		child := *g
+		child.PerformMove(move)
+
+		defense := &Node{
+			Move: *move,
+			SAN: g.SAN(move),
+			Mate: child.Outcome() == chess.Win(us.Opponent()),
+		}
+
+		if defense.Mate == false {
+			for _, cont := range child.CollectMoves() {
+				next := child
+				next.PerformMove(cont)
+
+				if _, ok := selfEscape(&next, n - 1, us); ok == false {
+					node := &Node{ Move: *cont, SAN: child.SAN(cont) }
+					node.Replies = selfDefenses(&next, n - 1, us)
+					defense.Replies = append(defense.Replies, node)
+				}
+			}
+		}
+
+		nodes = append(nodes, defense)
+	}
+
+	return nodes
+}
@@ -0,0 +1,367 @@
+package stats
+
+import "../pgn"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Results are the wins, draws and losses of a player.
+type Results struct {
+	Wins, Draws, Losses int
+}
+
+// Pentanomial counts pairs of games, played from the same opening with
+// the colors swapped, by the points the player scored in them: 0, ½,
+// 1, 1½ or 2. Pairs hide the advantage of an opening, so they give
+// tighter error bars than the games on their own.
+type Pentanomial [5]int
+
+// the quantile of the normal distribution for a 95% confidence interval
+const z95 = 1.959964
+
+// a distribution of outcomes, each a score from 0 to 1, and how many
+// times it happened
+type distribution struct {
+	scores []float64
+	counts []int
+}
+
+func (r Results) distribution() distribution {
+	return distribution{
+		scores: []float64{ 1, 0.5, 0 },
+		counts: []int{ r.Wins, r.Draws, r.Losses },
+	}
+}
+
+func (p Pentanomial) distribution() distribution {
+	return distribution{
+		scores: []float64{ 0, 0.25, 0.5, 0.75, 1 },
+		counts: p[:],
+	}
+}
+
+func (d distribution) n() int {
+	n := 0
+
+	for _, c := range d.counts {
+		n += c
+	}
+
+	return n
+}
+
+// mean score and its variance for a single outcome
+func (d distribution) stats() (mean, variance float64) {
+	n := float64(d.n())
+
+	if n == 0 {
+		return 0.5, 0
+	}
+
+	for i, c := range d.counts {
+		mean += d.scores[i] * float64(c) / n
+	}
+
+	for i, c := range d.counts {
+		variance += (d.scores[i] - mean) * (d.scores[i] - mean) * float64(c) / n
+	}
+
+	return mean, variance
+}
+
+// elo with the margin of its 95% confidence interval
+func (d distribution) elo() (float64, float64) {
+	mean, variance := d.stats()
+
+	if d.n() == 0 {
+		return 0, 0
+	}
+
+	stderr := math.Sqrt(variance / float64(d.n()))
+	low, high := Elo(mean - z95 * stderr), Elo(mean + z95 * stderr)
+
+	return Elo(mean), (high - low) / 2
+}
+
+// Elo is the rating difference giving an expected score from 0 to 1,
+// with the logistic model. A score of 0 or 1 is infinite.
+func Elo(score float64) float64 {
+	if score <= 0 {
+		return math.Inf(-1)
+	}
+
+	if score >= 1 {
+		return math.Inf(1)
+	}
+
+	return 400 * math.Log10(score / (1 - score))
+}
+
+// Score is the expected score of a player rated elo more than the
+// opponent.
+func Score(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo / 400))
+}
+
+func (r Results) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// Score is the points scored out of 1.
+func (r Results) Score() float64 {
+	mean, _ := r.distribution().stats()
+	return mean
+}
+
+func (r Results) DrawRatio() float64 {
+	if r.Games() == 0 {
+		return 0
+	}
+	return float64(r.Draws) / float64(r.Games())
+}
+
+// Elo is the rating difference with the margin of error of a 95%
+// confidence interval.
+func (r Results) Elo() (elo, margin float64) {
+	return r.distribution().elo()
+}
+
+// LOS is the likelihood of superiority, the chance the player is
+// stronger. Draws don't count.
+func (r Results) LOS() float64 {
+	if r.Wins + r.Losses == 0 {
+		return 0.5
+	}
+
+	return 0.5 * (1 + math.Erf(float64(r.Wins - r.Losses) / math.Sqrt(2 * float64(r.Wins + r.Losses))))
+}
+
+func (r Results) LLR(s SPRT) float64 {
+	return s.llr(r.distribution())
+}
+
+func (r Results) String() string {
+	elo, margin := r.Elo()
+
+	return fmt.Sprintf("%d - %d - %d [%.3f] %d, elo %s, LOS %.1f%%, draw ratio %.1f%%",
+		r.Wins,
+		r.Losses,
+		r.Draws,
+		r.Score(),
+		r.Games(),
+		formatElo(elo, margin),
+		100 * r.LOS(),
+		100 * r.DrawRatio())
+}
+
+func (p Pentanomial) Pairs() int {
+	return p.distribution().n()
+}
+
+// Score is the points scored out of 1 per game.
+func (p Pentanomial) Score() float64 {
+	mean, _ := p.distribution().stats()
+	return mean
+}
+
+func (p Pentanomial) Elo() (elo, margin float64) {
+	return p.distribution().elo()
+}
+
+// LOS is the likelihood of superiority, from the normal approximation
+// of the mean score of a pair.
+func (p Pentanomial) LOS() float64 {
+	mean, variance := p.distribution().stats()
+
+	if variance == 0 {
+		switch {
+			case mean > 0.5: return 1
+			case mean < 0.5: return 0
+		}
+		return 0.5
+	}
+
+	stderr := math.Sqrt(variance / float64(p.Pairs()))
+
+	return 0.5 * (1 + math.Erf((mean - 0.5) / stderr / math.Sqrt2))
+}
+
+func (p Pentanomial) LLR(s SPRT) float64 {
+	return s.llr(p.distribution())
+}
+
+func (p Pentanomial) String() string {
+	elo, margin := p.Elo()
+
+	return fmt.Sprintf("[%d, %d, %d, %d, %d] %d pairs, elo %s, LOS %.1f%%",
+		p[0], p[1], p[2], p[3], p[4],
+		p.Pairs(),
+		formatElo(elo, margin),
+		100 * p.LOS())
+}
+
+func formatElo(elo, margin float64) string {
+	return fmt.Sprintf("%+.1f +/- %.1f", elo, margin)
+}
+
+// An SPRT is a sequential probability ratio test of whether a player
+// is Elo1 stronger (H1) rather than Elo0 (H0), wrongly passing with a
+// chance of Alpha and wrongly failing with a chance of Beta. The test
+// goes on until the log likelihood ratio leaves its bounds.
+type SPRT struct {
+	Elo0, Elo1 float64
+	Alpha, Beta float64
+}
+
+var DefaultSPRT = SPRT{ Elo0: 0, Elo1: 5, Alpha: 0.05, Beta: 0.05 }
+
+type Decision int
+
+const (
+	Continue Decision = iota
+	Pass                      // H1 is accepted
+	Fail                      // H0 is accepted
+)
+
+var decisions = map[Decision]string{
+	Continue: "continue",
+	Pass: "H1 accepted",
+	Fail: "H0 accepted",
+}
+
+func (d Decision) String() string {
+	return decisions[d]
+}
+
+// Bounds of the log likelihood ratio where the test stops.
+func (s SPRT) Bounds() (lower, upper float64) {
+	return math.Log(s.Beta / (1 - s.Alpha)), math.Log((1 - s.Beta) / s.Alpha)
+}
+
+// llr approximates the log likelihood ratio of the two hypotheses
+// with a normal distribution of the mean score (the generalized SPRT).
+func (s SPRT) llr(d distribution) float64 {
+	mean, variance := d.stats()
+
+	if variance == 0 {
+		return 0
+	}
+
+	s0, s1 := Score(s.Elo0), Score(s.Elo1)
+
+	return float64(d.n()) * (s1 - s0) * (2 * mean - s0 - s1) / (2 * variance)
+}
+
+func (s SPRT) Decide(llr float64) Decision {
+	lower, upper := s.Bounds()
+
+	switch {
+		case llr >= upper: return Pass
+		case llr <= lower: return Fail
+	}
+
+	return Continue
+}
+
+// Points scored by a player in a game, named by the White or Black tag,
+// and false if they didn't play it or it isn't finished.
+func Points(game *pgn.PGN, player string) (float64, bool) {
+	var points float64
+
+	switch game.Result {
+		case pgn.WhiteWins:
+			points = 1
+			break
+		case pgn.Draw:
+			points = 0.5
+			break
+		case pgn.BlackWins:
+			points = 0
+			break
+		default:
+			return 0, false
+	}
+
+	switch player {
+		case game.Tags["White"]: return points, true
+		case game.Tags["Black"]: return 1 - points, true
+	}
+
+	return 0, false
+}
+
+// Add counts the result of a game for a player.
+func (r *Results) Add(game *pgn.PGN, player string) bool {
+	points, ok := Points(game, player)
+
+	switch {
+		case ok == false:
+			return false
+		case points == 1:
+			r.Wins++
+			break
+		case points == 0:
+			r.Losses++
+			break
+		default:
+			r.Draws++
+			break
+	}
+
+	return true
+}
+
+// Collect counts the results of a player in games, and the pairs of
+// games they make. Games are paired by their Round tags, as written
+// by the match package (rounds 1 and 2 are a pair, then 3 and 4), or
+// else in the order given. Games left without a pair only count as
+// results.
+func Collect(games []*pgn.PGN, player string) (Results, Pentanomial) {
+	var r Results
+	var p Pentanomial
+
+	// only pair by rounds when every game has one
+	rounds := make([]int, len(games))
+	byRound := true
+
+	for i, game := range games {
+		round, err := strconv.Atoi(game.Tags["Round"])
+
+		if err != nil || round < 1 {
+			byRound = false
+		}
+
+		rounds[i] = round
+	}
+
+	pairs := make(map[int][]float64)
+
+	for i, game := range games {
+		points, ok := Points(game, player)
+
+		if ok == false {
+			continue
+		}
+
+		r.Add(game, player)
+
+		pair := i / 2
+
+		if byRound {
+			pair = (rounds[i] - 1) / 2
+		}
+
+		pairs[pair] = append(pairs[pair], points)
+	}
+
+	for _, points := range pairs {
+		if len(points) == 2 {
+			p[int((points[0] + points[1]) * 2)]++
+		}
+	}
+
+	return r, p
+}
@@ -0,0 +1,290 @@
+package suite
+
+import (
+	"../chess"
+	"../epd"
+	"../fen"
+	"../search"
+	"../uci"
+)
+
+import (
+	"fmt"
+	"time"
+)
+
+// Progress is what a search has found so far.
+type Progress struct {
+	Move chess.Move           // best move
+	Score int                 // for the player to move, on the search scale
+	Depth int
+	Nodes int
+	Time time.Duration        // since the search started
+}
+
+// A Searcher searches test positions, with the local search or an
+// external engine, calling progress whenever it finishes a depth.
+type Searcher interface {
+	Search(g *chess.Game, progress func(Progress)) (Progress, error)
+}
+
+// Local searches with a search.Searcher. A MoveTime stops each search
+// after that long, on top of the other limits.
+type Local struct {
+	Searcher *search.Searcher
+	Limits search.Limits
+	MoveTime time.Duration
+}
+
+// Engine searches with an external UCI engine.
+type Engine struct {
+	Client *uci.Client
+	Limits uci.Go
+}
+
+func (l *Local) Search(g *chess.Game, progress func(Progress)) (Progress, error) {
+	limits := l.Limits
+	start := time.Now()
+
+	// every position starts from an empty table, so the results don't
+	// depend on the order of the suite
+	l.Searcher.Table.Clear()
+
+	if l.MoveTime > 0 {
+		stop := make(chan bool)
+		timer := time.AfterFunc(l.MoveTime, func() { close(stop) })
+
+		defer timer.Stop()
+
+		limits.Stop = stop
+	}
+
+	l.Searcher.Info = func(r search.Result) {
+		progress(Progress{ Move: r.Move, Score: r.Score, Depth: r.Depth, Nodes: r.Nodes, Time: time.Since(start) })
+	}
+
+	defer func() { l.Searcher.Info = nil }()
+
+	r := l.Searcher.Search(g, limits)
+
+	return Progress{ Move: r.Move, Score: r.Score, Depth: r.Depth, Nodes: r.Nodes, Time: time.Since(start) }, nil
+}
+
+func (e *Engine) Search(g *chess.Game, progress func(Progress)) (Progress, error) {
+	var last Progress
+
+	if err := e.Client.NewGame(); err != nil {
+		return last, err
+	}
+
+	if err := e.Client.Position(fen.Format(g), nil); err != nil {
+		return last, err
+	}
+
+	e.Client.Info = func(info uci.Info) {
+		if info.MultiPV > 1 || info.Bound != "" || len(info.PV) == 0 {
+			return
+		}
+
+		if move := g.ParseUCI(info.PV[0]); move != nil {
+			last = Progress{ Move: *move, Score: score(info), Depth: info.Depth, Nodes: info.Nodes, Time: info.Time }
+			progress(last)
+		}
+	}
+
+	defer func() { e.Client.Info = nil }()
+
+	a, err := e.Client.Go(e.Limits)
+
+	if err != nil {
+		return last, err
+	}
+
+	// the best move is final, even if the engine didn't send a pv
+	if move := g.ParseUCI(a.BestMove); move != nil {
+		last.Move = *move
+	}
+
+	return last, nil
+}
+
+// score of an engine's info on the search scale
+func score(info uci.Info) int {
+	switch {
+		case info.Mate > 0: return search.Mate - (info.Mate * 2 - 1)
+		case info.Mate < 0: return -search.Mate - info.Mate * 2
+	}
+
+	return info.Score
+}
+
+// scores in pawns, or moves to mate
+func formatScore(score int) string {
+	if search.IsMate(score) {
+		return fmt.Sprintf("#%d", search.MateMoves(score))
+	}
+	return fmt.Sprintf("%+.2f", float64(score) / 100)
+}
+
+// Result is how a searcher did on a position.
+type Result struct {
+	Position *epd.EPD
+	Progress                  // the final best move and its search
+	Solved bool
+	SolveTime time.Duration   // when the solution was found for good
+	SolveDepth int            // and at what depth
+}
+
+// Summary is the totals of a suite.
+type Summary struct {
+	Positions int
+	Solved int
+	Time time.Duration        // total search time
+	SolveTime time.Duration   // total time to find the solved moves
+	Nodes int
+}
+
+// Correct checks a move against the bm and am operations. A position
+// with neither can't be solved.
+func Correct(e *epd.EPD, move *chess.Move) bool {
+	if len(e.BestMoves) == 0 && len(e.AvoidMoves) == 0 {
+		return false
+	}
+
+	for _, bm := range e.BestMoves {
+		if search.SameMove(bm, move) {
+			return true
+		}
+	}
+
+	for _, am := range e.AvoidMoves {
+		if search.SameMove(am, move) {
+			return false
+		}
+	}
+
+	return len(e.BestMoves) == 0
+}
+
+// Run searches every position of a suite, calling report as each one
+// is done.
+func Run(positions []*epd.EPD, s Searcher, report func(Result)) (Summary, error) {
+	var sum Summary
+
+	for _, e := range positions {
+		r, err := Solve(e, s)
+
+		if err != nil {
+			return sum, fmt.Errorf("%s: %s", Name(e), err)
+		}
+
+		sum.Positions++
+		sum.Time += r.Time
+		sum.Nodes += r.Nodes
+
+		if r.Solved {
+			sum.Solved++
+			sum.SolveTime += r.SolveTime
+		}
+
+		if report != nil {
+			report(r)
+		}
+	}
+
+	return sum, nil
+}
+
+// Solve searches a single position.
+func Solve(e *epd.EPD, s Searcher) (Result, error) {
+	r := Result{ Position: e }
+
+	// when the best move became correct, and stayed so
+	found := false
+
+	final, err := s.Search(e.Game, func(p Progress) {
+		switch {
+			case Correct(e, &p.Move) == false:
+				found = false
+				break
+			case found == false:
+				found = true
+				r.SolveTime = p.Time
+				r.SolveDepth = p.Depth
+				break
+		}
+	})
+
+	if err != nil {
+		return r, err
+	}
+
+	r.Progress = final
+	r.Solved = Correct(e, &final.Move)
+
+	// the move may only have been seen at the very end
+	if r.Solved && found == false {
+		r.SolveTime = final.Time
+		r.SolveDepth = final.Depth
+	}
+
+	return r, nil
+}
+
+// Name is the id of a position, or its FEN if it has none.
+func Name(e *epd.EPD) string {
+	if e.ID != "" {
+		return e.ID
+	}
+	return fen.Format(e.Game)
+}
+
+// String is a line of the report for a position.
+func (r Result) String() string {
+	g := r.Position.Game
+	s := fmt.Sprintf("%-20s %-8s %8s depth %2d", Name(r.Position), g.SAN(&r.Move), formatScore(r.Score), r.Depth)
+
+	if r.Solved {
+		return fmt.Sprintf("%s  solved in %s at depth %d", s, r.SolveTime.Round(time.Millisecond), r.SolveDepth)
+	}
+
+	// show what was expected
+	expected := ""
+
+	if len(r.Position.BestMoves) > 0 {
+		expected += " bm"
+
+		for _, m := range r.Position.BestMoves {
+			expected += " " + g.SAN(m)
+		}
+	}
+
+	if len(r.Position.AvoidMoves) > 0 {
+		expected += " am"
+
+		for _, m := range r.Position.AvoidMoves {
+			expected += " " + g.SAN(m)
+		}
+	}
+
+	return s + "  FAILED," + expected
+}
+
+func (sum Summary) String() string {
+	if sum.Positions == 0 {
+		return "no positions"
+	}
+
+	s := fmt.Sprintf("solved %d of %d (%.1f%%) in %s, %d nodes",
+		sum.Solved,
+		sum.Positions,
+		100 * float64(sum.Solved) / float64(sum.Positions),
+		sum.Time.Round(time.Millisecond),
+		sum.Nodes)
+
+	if sum.Solved > 0 {
+		s += fmt.Sprintf(", average solve time %s", (sum.SolveTime / time.Duration(sum.Solved)).Round(time.Millisecond))
+	}
+
+	return s
+}
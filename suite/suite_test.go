@@ -0,0 +1,12 @@
+package suite_test
+
+import (
+	"../search"
+	"../suite"
+)
+
+import "testing"
+
+func TestTactics(t *testing.T) {
+	suite.Test(t, "testdata/tactics.epd", search.Limits{ Depth: 6 })
+}
@@ -0,0 +1,43 @@
+package suite
+
+import (
+	"../epd"
+	"../search"
+)
+
+import "testing"
+
+// Test runs a suite file under go test with the local search, each
+// position a subtest that fails unless it's solved within the limits.
+// Run a single position with go test -run, by its id.
+func Test(t *testing.T, file string, limits search.Limits) {
+	TestSearcher(t, file, &Local{ Searcher: search.New(), Limits: limits })
+}
+
+// TestSearcher is Test with any Searcher, like an external engine.
+func TestSearcher(t *testing.T, file string, s Searcher) {
+	t.Helper()
+
+	positions, err := epd.ParseFile(file)
+
+	if err != nil {
+		t.Fatalf("%s: %s", file, err)
+	}
+
+	for _, e := range positions {
+		t.Run(Name(e), func(t *testing.T) {
+			r, err := Solve(e, s)
+
+			switch {
+				case err != nil:
+					t.Fatal(err)
+				case r.Solved == false:
+					t.Error(r)
+					break
+				case testing.Verbose():
+					t.Log(r)
+					break
+			}
+		})
+	}
+}
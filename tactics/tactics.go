@@ -0,0 +1,349 @@
+package tactics
+
+import "../chess"
+
+type Theme int
+
+const (
+	Fork Theme = iota
+	Pin
+	RelativePin
+	Skewer
+	DiscoveredAttack
+	DiscoveredCheck
+	RemovalOfDefender
+	BackRank
+	Hanging
+)
+
+// A Motif is a tactical theme created or exploited by a move, with
+// the piece creating it and the pieces involved.
+type Motif struct {
+	Theme Theme               // what kind of tactic
+	Attacker int              // tile of the piece creating it
+	Targets []int             // tiles of the pieces involved, in order
+}
+
+// theme names match the puzzle theme tags used by lichess
+var themeNames = map[Theme]string{
+	Fork: "fork",
+	Pin: "pin",
+	RelativePin: "relativePin",
+	Skewer: "skewer",
+	DiscoveredAttack: "discoveredAttack",
+	DiscoveredCheck: "discoveredCheck",
+	RemovalOfDefender: "capturingDefender",
+	BackRank: "backRankThreat",
+	Hanging: "hangingPiece",
+}
+
+func (t Theme) String() string {
+	return themeNames[t]
+}
+
+// Detect returns all the motifs a legal move for the player to move
+// creates or exploits.
+func Detect(g *chess.Game, move *chess.Move) []Motif {
+	us := g.Turn
+	motifs := make([]Motif, 0, 4)
+
+	// examine the position after the move
+	after := *g
+	after.PerformMove(move)
+
+	motifs = append(motifs, forks(&after, move.Dest, us)...)
+	motifs = append(motifs, lines(&after, move.Dest, us)...)
+	motifs = append(motifs, discovered(g, &after, move, us)...)
+	motifs = append(motifs, removal(g, &after, move, us)...)
+	motifs = append(motifs, backRank(g, &after, us)...)
+	motifs = append(motifs, hanging(g, &after, move, us)...)
+
+	return motifs
+}
+
+// Themes returns the distinct themes of a list of motifs, in order.
+func Themes(motifs []Motif) []Theme {
+	themes := make([]Theme, 0, len(motifs))
+	seen := make(map[Theme]bool)
+
+	for _, m := range motifs {
+		if seen[m.Theme] == false {
+			themes = append(themes, m.Theme)
+			seen[m.Theme] = true
+		}
+	}
+
+	return themes
+}
+
+// the tiles a piece attacks
+func attacks(g *chess.Game, tile int) chess.Bitboard {
+	p := g.Position.Piece(tile)
+
+	if p == nil {
+		return 0
+	}
+
+	sq := chess.Square(tile)
+
+	if p.Kind == chess.Pawn {
+		return chess.PawnAttacks[p.Color][sq]
+	}
+
+	return chess.Attacks(p.Kind, sq, g.Bits.Occupied)
+}
+
+// a target is worth attacking if it's the king, worth more than the
+// attacker, or can be won outright
+func valuable(g *chess.Game, attacker, target int, us chess.Color) bool {
+	a := g.Position.Piece(attacker)
+	t := g.Position.Piece(target)
+
+	switch {
+		case t.Kind == chess.King:
+			return true
+		case chess.PieceValue[t.Kind] > chess.PieceValue[a.Kind]:
+			return true
+	}
+
+	return g.SEETile(target, us) > 0
+}
+
+func forks(g *chess.Game, tile int, us chess.Color) []Motif {
+	targets := make([]int, 0, 2)
+
+	// enemy pieces attacked by the moved piece
+	x := attacks(g, tile) & g.Bits.Colors[us.Opponent()]
+
+	for _, target := range x.Tiles() {
+		if valuable(g, tile, target, us) {
+			targets = append(targets, target)
+		}
+	}
+
+	if len(targets) < 2 {
+		return nil
+	}
+
+	return []Motif{ Motif{Theme: Fork, Attacker: tile, Targets: targets} }
+}
+
+// pins and skewers by the moved piece, looking along each direction
+// it slides for two enemy pieces in a row
+func lines(g *chess.Game, tile int, us chess.Color) []Motif {
+	var motifs []Motif
+
+	p := g.Position.Piece(tile)
+
+	if p == nil || p.Kind.Sliding() == false {
+		return nil
+	}
+
+	for _, d := range chess.PieceDelta[p.Kind] {
+		pieces := make([]int, 0, 2)
+
+		for x := tile + d; !chess.Offboard(x) && len(pieces) < 2; x += d {
+			if q := g.Position.Piece(x); q != nil {
+				if q.Color == us {
+					break
+				}
+
+				pieces = append(pieces, x)
+			}
+		}
+
+		if len(pieces) < 2 {
+			continue
+		}
+
+		front := g.Position.Piece(pieces[0])
+		back := g.Position.Piece(pieces[1])
+
+		motif := Motif{Attacker: tile, Targets: pieces}
+
+		switch {
+			case back.Kind == chess.King:
+				motif.Theme = Pin
+				break
+			case front.Kind == chess.King:
+				motif.Theme = Skewer
+				break
+			case chess.PieceValue[back.Kind] > chess.PieceValue[front.Kind]:
+				motif.Theme = RelativePin
+				break
+			case valuable(g, tile, pieces[0], us):
+				motif.Theme = Skewer
+				break
+			default:
+				continue
+		}
+
+		motifs = append(motifs, motif)
+	}
+
+	return motifs
+}
+
+// sliders attacking through the tile the moved piece left
+func discovered(before, after *chess.Game, move *chess.Move, us chess.Color) []Motif {
+	var motifs []Motif
+
+	origin := chess.Square(move.Origin)
+	opp := after.Bits.Colors[us.Opponent()]
+
+	for _, kind := range [...]chess.Kind{ chess.Bishop, chess.Rook, chess.Queen } {
+		for pieces := after.Bits.Pieces[us][kind]; pieces != 0; {
+			sq := pieces.Pop()
+			tile := chess.SquareTile(sq)
+
+			if tile == move.Dest {
+				continue
+			}
+
+			// newly attacked enemy pieces behind the origin
+			x := chess.Attacks(kind, sq, after.Bits.Occupied) &^ chess.Attacks(kind, sq, before.Bits.Occupied)
+
+			for x &= opp; x != 0; {
+				target := x.Pop()
+
+				if chess.Between[sq][target].Has(origin) == false {
+					continue
+				}
+
+				motif := Motif{
+					Theme: DiscoveredAttack,
+					Attacker: tile,
+					Targets: []int{ chess.SquareTile(target) },
+				}
+
+				if after.Bits.Pieces[us.Opponent()][chess.King].Has(target) {
+					motif.Theme = DiscoveredCheck
+				} else if valuable(after, tile, motif.Targets[0], us) == false {
+					continue
+				}
+
+				motifs = append(motifs, motif)
+			}
+		}
+	}
+
+	return motifs
+}
+
+// capturing a piece that was defending another, now left hanging
+func removal(before, after *chess.Game, move *chess.Move, us chess.Color) []Motif {
+	var motifs []Motif
+
+	if move.Capture == false || move.EnPassant {
+		return nil
+	}
+
+	defended := attacks(before, move.Dest) & before.Bits.Colors[us.Opponent()]
+
+	for defended != 0 {
+		tile := chess.SquareTile(defended.Pop())
+
+		if before.SEETile(tile, us) <= 0 && after.SEETile(tile, us) > 0 {
+			motifs = append(motifs, Motif{
+				Theme: RemovalOfDefender,
+				Attacker: move.Dest,
+				Targets: []int{ move.Dest, tile },
+			})
+		}
+	}
+
+	return motifs
+}
+
+// rooks and queens able to check a king with no way off its back rank
+func backRankThreats(g *chess.Game, us chess.Color) []Motif {
+	var motifs []Motif
+
+	them := us.Opponent()
+	king := g.King[them]
+
+	if king < 0 || chess.Rank(king) != chess.BackRank[them] {
+		return nil
+	}
+
+	ksq := chess.Square(king)
+	rank := chess.Line[ksq][chess.Square(chess.Tile(chess.Rank(king), 0))]
+
+	if chess.File(king) == 0 {
+		rank = chess.Line[ksq][chess.Square(chess.Tile(chess.Rank(king), 7))]
+	}
+
+	// every flight square off the back rank must be covered
+	for flight := chess.KingAttacks[ksq] &^ rank; flight != 0; {
+		sq := flight.Pop()
+
+		if g.Bits.Colors[them].Has(sq) == false && g.Bits.Attacked(sq, us) == false {
+			return nil
+		}
+	}
+
+	heavy := g.Bits.Pieces[us][chess.Rook] | g.Bits.Pieces[us][chess.Queen]
+
+	for heavy != 0 {
+		sq := heavy.Pop()
+
+		// back rank tiles the piece can reach that see the king
+		entries := chess.RookAttacks(sq, g.Bits.Occupied) & rank &^ g.Bits.Colors[us]
+
+		for entries != 0 {
+			x := entries.Pop()
+
+			if x != ksq && chess.Between[x][ksq] & g.Bits.Occupied == 0 {
+				motifs = append(motifs, Motif{
+					Theme: BackRank,
+					Attacker: chess.SquareTile(sq),
+					Targets: []int{ king, chess.SquareTile(x) },
+				})
+			}
+		}
+	}
+
+	return motifs
+}
+
+func backRank(before, after *chess.Game, us chess.Color) []Motif {
+	if len(backRankThreats(before, us)) > 0 {
+		return nil
+	}
+
+	return backRankThreats(after, us)
+}
+
+// capturing a free piece, or attacking undefended ones
+func hanging(before, after *chess.Game, move *chess.Move, us chess.Color) []Motif {
+	var motifs []Motif
+
+	if move.Capture && !move.EnPassant {
+		if x := before.Position.Piece(move.Dest); before.SEE(move) >= chess.PieceValue[x.Kind] {
+			motifs = append(motifs, Motif{
+				Theme: Hanging,
+				Attacker: move.Origin,
+				Targets: []int{ move.Dest },
+			})
+		}
+	}
+
+	x := attacks(after, move.Dest) & after.Bits.Colors[us.Opponent()]
+
+	// the king is never hanging, it's in check
+	x &^= after.Bits.Pieces[us.Opponent()][chess.King]
+
+	for x != 0 {
+		tile := chess.SquareTile(x.Pop())
+
+		if after.SEETile(tile, us) > 0 && before.SEETile(tile, us) <= 0 {
+			motifs = append(motifs, Motif{
+				Theme: Hanging,
+				Attacker: move.Dest,
+				Targets: []int{ tile },
+			})
+		}
+	}
+
+	return motifs
+}
@@ -0,0 +1,107 @@
+package tactics_test
+
+import (
+	"../chess"
+	"../fen"
+	"../tactics"
+)
+
+import "testing"
+
+var motifCases = []struct {
+	name string
+	fen string
+	move string
+	theme tactics.Theme
+	found bool
+}{
+	{ "knight forks king and rook", "r3k3/8/8/1N6/8/8/8/4K3 w - - 0 1", "b5c7", tactics.Fork, true },
+	{ "knight attacks one piece", "r3k3/8/8/8/8/8/8/1N2K3 w - - 0 1", "b1c3", tactics.Fork, false },
+
+	{ "bishop pins knight to king", "4k3/8/2n5/8/8/8/8/4KB2 w - - 0 1", "f1b5", tactics.Pin, true },
+	{ "bishop attacks knight", "3k4/8/2n5/8/8/8/8/4KB2 w - - 0 1", "f1b5", tactics.Pin, false },
+	{ "bishop pins knight to queen", "4k3/3q4/2n5/8/8/8/8/4KB2 w - - 0 1", "f1b5", tactics.RelativePin, true },
+	{ "bishop pins knight to pawn", "4k3/3p4/2n5/8/8/8/8/4KB2 w - - 0 1", "f1b5", tactics.RelativePin, false },
+
+	{ "rook skewers king and queen", "4k2q/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", tactics.Skewer, true },
+	{ "rook checks king", "4k3/8/8/8/8/8/8/R3K3 w - - 0 1", "a1a8", tactics.Skewer, false },
+
+	{ "knight uncovers rook on queen", "4k3/4q3/8/8/4N3/8/8/4RK2 w - - 0 1", "e4c5", tactics.DiscoveredAttack, true },
+	{ "knight uncovers rook on defended pawn", "4k3/3pp3/8/8/4N3/8/8/4RK2 w - - 0 1", "e4c5", tactics.DiscoveredAttack, false },
+	{ "knight uncovers check", "4k3/8/8/8/4N3/8/8/4RK2 w - - 0 1", "e4c5", tactics.DiscoveredCheck, true },
+	{ "knight leaves closed file", "4k3/4p3/8/8/4N3/8/8/4RK2 w - - 0 1", "e4c5", tactics.DiscoveredCheck, false },
+
+	{ "bishop takes defender of bishop", "k7/8/5n2/3b2B1/8/8/8/3RK3 w - - 0 1", "g5f6", tactics.RemovalOfDefender, true },
+	{ "bishop takes knight defending nothing", "k7/8/5n2/6B1/8/8/8/3RK3 w - - 0 1", "g5f6", tactics.RemovalOfDefender, false },
+
+	{ "rook opens file to back rank", "6k1/5ppp/8/8/8/3P4/3R4/6K1 w - - 0 1", "d2c2", tactics.BackRank, true },
+	{ "king has luft", "6k1/5pp1/7p/8/8/3P4/3R4/6K1 w - - 0 1", "d2c2", tactics.BackRank, false },
+
+	{ "rook takes free knight", "4k3/8/8/3n4/8/8/8/3RK3 w - - 0 1", "d1d5", tactics.Hanging, true },
+	{ "rook takes defended knight", "4k3/8/4p3/3n4/8/8/8/3RK3 w - - 0 1", "d1d5", tactics.Hanging, false },
+}
+
+func TestDetect(t *testing.T) {
+	for _, c := range motifCases {
+		g := fen.Parse(c.fen)
+
+		if g == nil {
+			t.Fatalf("%s: invalid FEN %s", c.name, c.fen)
+		}
+
+		move := find(g, c.move)
+
+		if move == nil {
+			t.Fatalf("%s: illegal move %s", c.name, c.move)
+		}
+
+		themes := tactics.Themes(tactics.Detect(g, move))
+
+		if has(themes, c.theme) != c.found {
+			t.Errorf("%s: %s gives %v, want %v found %v", c.name, c.move, themes, c.theme, c.found)
+		}
+	}
+}
+
+// quiet opening moves have no tactics at all
+func TestDetectQuiet(t *testing.T) {
+	g := chess.NewGame()
+
+	for _, s := range []string{ "d2d4", "d7d5", "g1f3", "g8f6" } {
+		move := find(g, s)
+
+		if motifs := tactics.Detect(g, move); len(motifs) > 0 {
+			t.Errorf("%s gives %v, want none", s, tactics.Themes(motifs))
+		}
+
+		g.PerformMove(move)
+	}
+}
+
+func has(themes []tactics.Theme, theme tactics.Theme) bool {
+	for _, t := range themes {
+		if t == theme {
+			return true
+		}
+	}
+	return false
+}
+
+// find the legal move between two squares, written like e2e4
+func find(g *chess.Game, s string) *chess.Move {
+	var list chess.MoveList
+
+	g.GenerateMoves(&list)
+
+	origin := chess.Tile(int(s[1] - '1'), int(s[0] - 'a'))
+	dest := chess.Tile(int(s[3] - '1'), int(s[2] - 'a'))
+
+	moves := list.Slice()
+
+	for i := range moves {
+		if moves[i].Origin == origin && moves[i].Dest == dest {
+			return &moves[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package tournament
+
+import "../pgn"
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// WriteCrosstable writes the standings as a table. A round robin shows
+// every player's results against every other, and a Swiss each round's
+// opponent (by rank), color and result.
+func (t *Tournament) WriteCrosstable(w io.Writer) error {
+	standings := t.Standings()
+	ranks := make(map[*Player]int)
+
+	for i, s := range standings {
+		ranks[s.Player] = i + 1
+	}
+
+	width := 4
+
+	for _, p := range t.Players {
+		if n := utf8.RuneCountInString(p.Name); n > width {
+			width = n
+		}
+	}
+
+	var b strings.Builder
+
+	header := []string{ pad("#", 3), pad("Name", width), pad("Rating", 6) }
+
+	for _, col := range t.columns() {
+		header = append(header, pad(col, t.cellWidth()))
+	}
+
+	header = append(header, pad("Pts", 5))
+
+	for _, tb := range t.Tiebreaks {
+		header = append(header, pad(tb.String(), 8))
+	}
+
+	b.WriteString(strings.TrimRight(strings.Join(header, " "), " ") + "\n")
+
+	for i, s := range standings {
+		row := []string{
+			pad(fmt.Sprint(s.Rank), 3),
+			pad(s.Player.Name, width),
+			pad(fmt.Sprint(s.Player.Rating), 6),
+		}
+
+		for _, cell := range t.cells(s.Player, i, standings, ranks) {
+			row = append(row, pad(cell, t.cellWidth()))
+		}
+
+		row = append(row, pad(fmt.Sprintf("%.1f", s.Points), 5))
+
+		for _, x := range s.Tiebreaks {
+			row = append(row, pad(fmt.Sprintf("%.2f", x), 8))
+		}
+
+		b.WriteString(strings.TrimRight(strings.Join(row, " "), " ") + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// column headings: ranks for a round robin, else rounds
+func (t *Tournament) columns() []string {
+	var cols []string
+
+	if t.System == RoundRobin {
+		for i := range t.Players {
+			cols = append(cols, fmt.Sprint(i + 1))
+		}
+	} else {
+		for r := 1; r <= t.Played(); r++ {
+			cols = append(cols, fmt.Sprintf("Rd%d", r))
+		}
+	}
+
+	return cols
+}
+
+func (t *Tournament) cellWidth() int {
+	if t.System == RoundRobin {
+		if t.Cycles > 1 {
+			return t.Cycles
+		}
+		return 1
+	}
+
+	// opponent rank, color and result, e.g. "12w½"
+	return len(fmt.Sprint(len(t.Players))) + 2
+}
+
+func (t *Tournament) cells(p *Player, row int, standings []*Standing, ranks map[*Player]int) []string {
+	var cells []string
+
+	if t.System == RoundRobin {
+		for i, s := range standings {
+			if i == row {
+				cells = append(cells, strings.Repeat("*", t.cellWidth()))
+				continue
+			}
+
+			cell := ""
+
+			for _, g := range t.Games {
+				if g.White == p && g.Black == s.Player || g.White == s.Player && g.Black == p {
+					cell += t.result(g, p)
+				}
+			}
+
+			cells = append(cells, cell)
+		}
+
+		return cells
+	}
+
+	for r := 1; r <= t.Played(); r++ {
+		cell := "-"
+
+		for _, g := range t.Round(r) {
+			switch {
+				case g.White == p && g.Black == nil:
+					cell = "bye"
+					break
+				case g.White == p:
+					cell = fmt.Sprintf("%dw%s", ranks[g.Black], t.result(g, p))
+					break
+				case g.Black == p:
+					cell = fmt.Sprintf("%db%s", ranks[g.White], t.result(g, p))
+					break
+			}
+		}
+
+		cells = append(cells, cell)
+	}
+
+	return cells
+}
+
+// result of a game for a player, or a space if unfinished
+func (t *Tournament) result(g *Game, p *Player) string {
+	if g.Result == pgn.InProgress {
+		return " "
+	}
+
+	white, black := t.points(g)
+	points := white
+
+	if g.Black == p {
+		points = black
+	}
+
+	switch points {
+		case 1: return "1"
+		case 0.5: return "½"
+		case 0: return "0"
+	}
+
+	return fmt.Sprint(points)
+}
+
+// pad text to a width in runes
+func pad(s string, width int) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width - n)
+	}
+	return s
+}
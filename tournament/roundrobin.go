@@ -0,0 +1,78 @@
+package tournament
+
+// Berger returns the pairings of a round of a round robin for n
+// players, from the FIDE Berger tables, as pairs of player numbers
+// from 1 with white first. With an odd number of players, the one
+// paired with n + 1 has a bye.
+func Berger(n, round int) [][2]int {
+	if n % 2 == 1 {
+		n++
+	}
+
+	// the first round pairs the top half against the bottom half
+	pairs := make([][2]int, n / 2)
+
+	for i := range pairs {
+		pairs[i] = [2]int{ i + 1, n - i }
+	}
+
+	// each round adds n/2 to every number but the last, modulo n-1,
+	// and the last player changes color on the first board
+	for r := 1; r < round; r++ {
+		for i := range pairs {
+			for j, x := range pairs[i] {
+				if x != n {
+					pairs[i][j] = (x - 1 + n / 2) % (n - 1) + 1
+				}
+			}
+		}
+
+		pairs[0][0], pairs[0][1] = pairs[0][1], pairs[0][0]
+	}
+
+	return pairs
+}
+
+// pairRoundRobin pairs a round from the Berger tables, with colors
+// reversed in every other cycle.
+func (t *Tournament) pairRoundRobin(round int) ([]*Game, error) {
+	n := len(t.Players)
+	rounds := n - 1 + n % 2
+
+	if n < 2 {
+		return nil, ErrNoPairing
+	}
+
+	if round > rounds * t.Cycles {
+		return nil, ErrFinished
+	}
+
+	cycle := (round - 1) / rounds
+	games := make([]*Game, 0, n / 2 + 1)
+
+	var bye *Game
+
+	for _, pair := range Berger(n, (round - 1) % rounds + 1) {
+		if cycle % 2 == 1 {
+			pair[0], pair[1] = pair[1], pair[0]
+		}
+
+		switch {
+			case pair[0] > n:
+				bye = &Game{ White: t.Players[pair[1] - 1] }
+				break
+			case pair[1] > n:
+				bye = &Game{ White: t.Players[pair[0] - 1] }
+				break
+			default:
+				games = append(games, &Game{ White: t.Players[pair[0] - 1], Black: t.Players[pair[1] - 1] })
+				break
+		}
+	}
+
+	if bye != nil {
+		games = append(games, bye)
+	}
+
+	return games, nil
+}
@@ -0,0 +1,145 @@
+package tournament
+
+import "../pgn"
+
+import "sort"
+
+type Tiebreak int
+
+const (
+	Buchholz Tiebreak = iota      // sum of the opponents' scores
+	BuchholzCut1                  // the same without the lowest
+	SonnebornBerger               // scores of the opponents beaten, and half of those drawn
+	Progressive                   // sum of the running score after each round
+)
+
+var tiebreakNames = map[Tiebreak]string{
+	Buchholz: "Buchholz",
+	BuchholzCut1: "Buch-1",
+	SonnebornBerger: "S-B",
+	Progressive: "Progr",
+}
+
+func (tb Tiebreak) String() string {
+	return tiebreakNames[tb]
+}
+
+// A Standing is a player's place in the tournament.
+type Standing struct {
+	Player *Player
+	Rank int                  // players tied on everything share a rank
+	Points float64
+	Games int                 // played, without byes
+	Tiebreaks []float64       // in the order of the tournament's
+}
+
+// Standings ranks the players by points, then by each tiebreak, using
+// the games finished so far.
+func (t *Tournament) Standings() []*Standing {
+	rounds := t.Played()
+	scores := t.scores(rounds)
+	standings := make([]*Standing, len(t.Players))
+
+	for i, p := range t.Players {
+		s := &Standing{ Player: p, Points: scores[p][rounds] }
+
+		for _, tb := range t.Tiebreaks {
+			s.Tiebreaks = append(s.Tiebreaks, t.tiebreak(tb, p, scores, rounds))
+		}
+
+		for _, g := range t.Games {
+			if g.Black != nil && g.Result != pgn.InProgress && (g.White == p || g.Black == p) {
+				s.Games++
+			}
+		}
+
+		standings[i] = s
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return above(standings[i], standings[j]) || (tied(standings[i], standings[j]) && standings[i].Player.Number < standings[j].Player.Number)
+	})
+
+	for i, s := range standings {
+		if s.Rank = i + 1; i > 0 && tied(s, standings[i - 1]) {
+			s.Rank = standings[i - 1].Rank
+		}
+	}
+
+	return standings
+}
+
+func above(a, b *Standing) bool {
+	if a.Points != b.Points {
+		return a.Points > b.Points
+	}
+
+	for i := range a.Tiebreaks {
+		if a.Tiebreaks[i] != b.Tiebreaks[i] {
+			return a.Tiebreaks[i] > b.Tiebreaks[i]
+		}
+	}
+
+	return false
+}
+
+func tied(a, b *Standing) bool {
+	return above(a, b) == false && above(b, a) == false
+}
+
+func (t *Tournament) tiebreak(tb Tiebreak, p *Player, scores map[*Player][]float64, rounds int) float64 {
+	if tb == Progressive {
+		sum := 0.0
+
+		for r := 1; r <= rounds; r++ {
+			sum += scores[p][r]
+		}
+
+		return sum
+	}
+
+	sum, lowest := 0.0, -1.0
+
+	for _, g := range t.Games {
+		if g.Black == nil || g.Result == pgn.InProgress {
+			continue
+		}
+
+		var opponent *Player
+		var points float64
+
+		switch p {
+			case g.White:
+				opponent = g.Black
+				points, _ = t.points(g)
+				break
+			case g.Black:
+				opponent = g.White
+				_, points = t.points(g)
+				break
+			default:
+				continue
+		}
+
+		score := scores[opponent][rounds]
+
+		switch tb {
+			case SonnebornBerger:
+				sum += points * score
+				break
+			default:
+				sum += score
+
+				if lowest < 0 || score < lowest {
+					lowest = score
+				}
+				break
+		}
+	}
+
+	if tb == BuchholzCut1 && lowest >= 0 {
+		sum -= lowest
+	}
+
+	return sum
+}
@@ -0,0 +1,512 @@
+package tournament
+
+import (
+	"sort"
+)
+
+// color preferences, strongest last
+const (
+	noPreference = iota
+	mild
+	strong
+	absolute
+)
+
+const (
+	white = 1
+	black = -1
+)
+
+// costs of floating a player the same way as in the last round, worse
+// than any color preference not met
+const (
+	repeatDownfloat = absolute + 2
+	repeatUpfloat = absolute + 1
+)
+
+// limits on the pairings tried, so large brackets can't take forever
+const (
+	maxCandidates = 5000
+	maxSteps = 100000
+)
+
+// what the pairing rules need to know of a player's past rounds
+type record struct {
+	p *Player
+	points float64
+	colors []int              // white or black in each game played
+	opponents map[*Player]bool
+	bye bool
+	downfloat bool            // paired down in the last round
+	upfloat bool              // paired up in the last round
+}
+
+type pairing struct {
+	white, black *record
+}
+
+// pairSwiss pairs a round with the Dutch system: players are split
+// into brackets by score, and in each the top half is paired against
+// the bottom half, with the bottom half transposed, or players
+// exchanged between the halves, until nobody meets an opponent twice
+// and the most color preferences are met. Whoever can't be paired
+// floats down to the next bracket. Players who floated down or up in
+// the last round are kept from floating the same way again, whenever
+// that's possible.
+func (t *Tournament) pairSwiss(round int) ([]*Game, error) {
+	records := t.records(round - 1)
+
+	// highest score first, then by number
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].points != records[j].points {
+			return records[i].points > records[j].points
+		}
+		return records[i].p.Number < records[j].p.Number
+	})
+
+	var bye *record
+
+	// the lowest player without a bye sits out, as long as the rest
+	// can still be paired, or anyone when everyone has had one
+	if len(records) % 2 == 1 {
+		for _, again := range []bool{ false, true } {
+			for i := len(records) - 1; i >= 0 && bye == nil; i-- {
+				rest := without(records, records[i])
+
+				if (again || records[i].bye == false) && pairable(rest) {
+					bye, records = records[i], rest
+				}
+			}
+		}
+
+		if bye == nil {
+			return nil, ErrNoPairing
+		}
+	}
+
+	pairings, ok := dutch(brackets(records), 0, nil)
+
+	if ok == false {
+		return nil, ErrNoPairing
+	}
+
+	// top boards have the highest scores
+	sort.SliceStable(pairings, func(i, j int) bool {
+		a, b := pairings[i], pairings[j]
+
+		if high(a) != high(b) {
+			return high(a) > high(b)
+		}
+		return top(a) < top(b)
+	})
+
+	games := make([]*Game, 0, len(pairings) + 1)
+
+	for _, pair := range pairings {
+		games = append(games, &Game{ White: pair.white.p, Black: pair.black.p })
+	}
+
+	if bye != nil {
+		games = append(games, &Game{ White: bye.p })
+	}
+
+	return games, nil
+}
+
+func high(pair pairing) float64 {
+	if pair.white.points > pair.black.points {
+		return pair.white.points
+	}
+	return pair.black.points
+}
+
+func top(pair pairing) int {
+	if pair.white.p.Number < pair.black.p.Number {
+		return pair.white.p.Number
+	}
+	return pair.black.p.Number
+}
+
+// records of every player after a number of rounds
+func (t *Tournament) records(rounds int) []*record {
+	scores := t.scores(rounds)
+	index := make(map[*Player]*record)
+	records := make([]*record, len(t.Players))
+
+	for i, p := range t.Players {
+		records[i] = &record{ p: p, points: scores[p][rounds], opponents: make(map[*Player]bool) }
+		index[p] = records[i]
+	}
+
+	for r := 1; r <= rounds; r++ {
+		for _, g := range t.Round(r) {
+			w := index[g.White]
+
+			if g.Black == nil {
+				w.bye = true
+				continue
+			}
+
+			b := index[g.Black]
+
+			w.colors = append(w.colors, white)
+			b.colors = append(b.colors, black)
+			w.opponents[g.Black] = true
+			b.opponents[g.White] = true
+
+			// floats are only remembered from the last round
+			if r == rounds {
+				before, after := scores[g.White][r - 1], scores[g.Black][r - 1]
+
+				w.downfloat, w.upfloat = before > after, before < after
+				b.downfloat, b.upfloat = after > before, after < before
+			}
+		}
+	}
+
+	return records
+}
+
+// preference is the color a player should get next, and how much.
+func (r *record) preference() (int, int) {
+	n := len(r.colors)
+
+	if n == 0 {
+		return 0, noPreference
+	}
+
+	diff := 0
+
+	for _, c := range r.colors {
+		diff += c
+	}
+
+	last := r.colors[n - 1]
+
+	switch {
+		case diff > 1:
+			return black, absolute
+		case diff < -1:
+			return white, absolute
+		case n > 1 && r.colors[n - 2] == last:
+			return -last, absolute
+		case diff == 1:
+			return black, strong
+		case diff == -1:
+			return white, strong
+	}
+
+	return -last, mild
+}
+
+// compatible players haven't met and don't both need the same color.
+func compatible(a, b *record) bool {
+	if a.opponents[b.p] {
+		return false
+	}
+
+	ca, sa := a.preference()
+	cb, sb := b.preference()
+
+	return sa != absolute || sb != absolute || ca != cb
+}
+
+// allocate gives the colors of a pair, with a ranked above b, and
+// the number of preferences it fails to meet, weighted by strength.
+// Players without a preference alternate by board from white for the
+// top player.
+func allocate(a, b *record, board int) (pairing, int) {
+	ca, sa := a.preference()
+	cb, sb := b.preference()
+
+	give := func(c int) pairing {
+		if c == white {
+			return pairing{ a, b }
+		}
+		return pairing{ b, a }
+	}
+
+	switch {
+		case sa == noPreference && sb == noPreference:
+			if board % 2 == 0 {
+				return give(white), 0
+			}
+			return give(black), 0
+		case sb == noPreference || (sa != noPreference && ca != cb):
+			return give(ca), 0
+		case sa == noPreference:
+			return give(-cb), 0
+		case sb > sa:
+			return give(-cb), sa
+	}
+
+	// the same preference: the higher ranked player gets it
+	return give(ca), sb
+}
+
+// brackets splits players into groups of the same score.
+func brackets(records []*record) [][]*record {
+	var groups [][]*record
+
+	for i, r := range records {
+		if i == 0 || r.points != records[i - 1].points {
+			groups = append(groups, nil)
+		}
+
+		groups[len(groups) - 1] = append(groups[len(groups) - 1], r)
+	}
+
+	return groups
+}
+
+// a way to pair a bracket, and how far it is from ideal
+type candidate struct {
+	pairings []pairing
+	floaters []*record
+	cost int
+}
+
+// dutch pairs the brackets from i onwards, with the players floating
+// down from the one before. The pairings of a bracket are tried best
+// first, and the next is only tried when the rest can't be paired.
+func dutch(groups [][]*record, i int, floaters []*record) ([]pairing, bool) {
+	if i == len(groups) {
+		return nil, len(floaters) == 0
+	}
+
+	bracket := append(append([]*record{}, floaters...), groups[i]...)
+
+	var below []*record
+
+	for _, g := range groups[i + 1:] {
+		below = append(below, g...)
+	}
+
+	// pair as many as possible, the last bracket has to pair everyone
+	for pairs := len(bracket) / 2; pairs >= 0; pairs-- {
+		if len(below) == 0 && pairs * 2 != len(bracket) {
+			break
+		}
+
+		for _, c := range candidates(bracket, pairs, below) {
+			if rest, ok := dutch(groups, i + 1, c.floaters); ok {
+				return append(c.pairings, rest...), true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// candidates of a bracket pairing its top players against the others,
+// in the order of the Dutch system: transpositions of the bottom half,
+// then exchanges between the halves. Only those leaving the players
+// below pairable are kept, best first. Exchanges swap a single player
+// of each half, the exchanges of two or more players the Dutch system
+// goes on to aren't tried, so a bracket only they would pair gets more
+// floaters or a worse pairing instead.
+func candidates(bracket []*record, pairs int, below []*record) []candidate {
+	var found []candidate
+
+	tried := 0
+
+	for _, x := range exchanges(len(bracket), pairs) {
+		s1 := make([]*record, pairs)
+		s2 := make([]*record, len(bracket) - pairs)
+
+		copy(s1, bracket[:pairs])
+		copy(s2, bracket[pairs:])
+
+		if x[0] >= 0 {
+			s1[x[0]], s2[x[1]] = s2[x[1]], s1[x[0]]
+		}
+
+		order := make([]int, len(s2))
+
+		for i := range order {
+			order[i] = i
+		}
+
+		for ok := true; ok && tried < maxCandidates; ok = nextPermutation(order) {
+			tried++
+
+			c, bad := pair(s1, s2, order)
+
+			// skip every order with the same players up to a bad pair,
+			// and those only changing the order of the floaters
+			if bad >= 0 {
+				sort.Sort(sort.Reverse(sort.IntSlice(order[bad + 1:])))
+				continue
+			}
+
+			sort.Sort(sort.Reverse(sort.IntSlice(order[pairs:])))
+
+			if pairable(append(append([]*record{}, c.floaters...), below...)) == false {
+				continue
+			}
+
+			// nothing can do better than the first perfect pairing
+			if c.cost == 0 {
+				return append([]candidate{ c }, found...)
+			}
+
+			found = append(found, c)
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].cost < found[j].cost
+	})
+
+	return found
+}
+
+// pair the top half against the bottom half in an order, with the
+// rest of the bottom half floating down. If two players can't meet,
+// the board they were on is returned, otherwise -1.
+func pair(s1, s2 []*record, order []int) (candidate, int) {
+	var c candidate
+
+	for i, a := range s1 {
+		b := s2[order[i]]
+
+		if compatible(a, b) == false {
+			return c, i
+		}
+
+		// a is ranked above b, unless exchanged
+		if b.points > a.points || (b.points == a.points && b.p.Number < a.p.Number) {
+			a, b = b, a
+		}
+
+		pairing, cost := allocate(a, b, i)
+
+		c.pairings = append(c.pairings, pairing)
+		c.cost += cost
+
+		// b meets a player floating down, nobody should be paired up
+		// twice in a row
+		if b.points < a.points && b.upfloat {
+			c.cost += repeatUpfloat
+		}
+	}
+
+	for _, j := range order[len(s1):] {
+		c.floaters = append(c.floaters, s2[j])
+
+		// nor float down twice in a row
+		if s2[j].downfloat {
+			c.cost += repeatDownfloat
+		}
+	}
+
+	return c, -1
+}
+
+// exchanges between the halves, as the index in the top half and the
+// index in the bottom half, starting with none, then those swapping
+// the players closest to the middle.
+func exchanges(n, pairs int) [][2]int {
+	x := [][2]int{ { -1, -1 } }
+
+	for d := 1; d < n; d++ {
+		for i := pairs - 1; i >= 0; i-- {
+			if j := d - (pairs - i); j >= 0 && j < n - pairs {
+				x = append(x, [2]int{ i, j })
+			}
+		}
+	}
+
+	return x
+}
+
+// nextPermutation rearranges indices into the next lexicographic
+// order, returning false after the last.
+func nextPermutation(p []int) bool {
+	i := len(p) - 2
+
+	for i >= 0 && p[i] >= p[i + 1] {
+		i--
+	}
+
+	if i < 0 {
+		return false
+	}
+
+	j := len(p) - 1
+
+	for p[j] <= p[i] {
+		j--
+	}
+
+	p[i], p[j] = p[j], p[i]
+
+	for a, b := i + 1, len(p) - 1; a < b; a, b = a + 1, b - 1 {
+		p[a], p[b] = p[b], p[a]
+	}
+
+	return true
+}
+
+// pairable is true when every player can be paired with someone they
+// haven't met, regardless of score.
+func pairable(records []*record) bool {
+	if len(records) % 2 == 1 {
+		return false
+	}
+
+	steps := 0
+	used := make([]bool, len(records))
+
+	var match func() bool
+
+	match = func() bool {
+		first := -1
+
+		for i, u := range used {
+			if u == false {
+				first = i
+				break
+			}
+		}
+
+		if first < 0 {
+			return true
+		}
+
+		used[first] = true
+
+		for i := first + 1; i < len(records); i++ {
+			if steps++; steps > maxSteps {
+				break
+			}
+
+			if used[i] == false && compatible(records[first], records[i]) {
+				used[i] = true
+
+				if match() {
+					return true
+				}
+
+				used[i] = false
+			}
+		}
+
+		used[first] = false
+		return false
+	}
+
+	return match()
+}
+
+func without(records []*record, r *record) []*record {
+	rest := make([]*record, 0, len(records) - 1)
+
+	for _, x := range records {
+		if x != r {
+			rest = append(rest, x)
+		}
+	}
+
+	return rest
+}
@@ -0,0 +1,144 @@
+package tournament_test
+
+import (
+	"../pgn"
+	"../tournament"
+)
+
+import (
+	"fmt"
+	"testing"
+)
+
+func swiss(players int) *tournament.Tournament {
+	t := tournament.New("test", tournament.Swiss)
+
+	for i := 1; i <= players; i++ {
+		t.Add(fmt.Sprintf("Player %d", i), 2000 - i * 10)
+	}
+
+	return t
+}
+
+// scores of the players before a round
+func points(t *tournament.Tournament) map[*tournament.Player]float64 {
+	scores := make(map[*tournament.Player]float64)
+
+	for _, s := range t.Standings() {
+		scores[s.Player] = s.Points
+	}
+
+	return scores
+}
+
+// everyone plays once a round, or has the bye, nobody meets the same
+// opponent twice and nobody has a second bye
+func TestSwissOddPlayers(t *testing.T) {
+	results := []int{ pgn.WhiteWins, pgn.Draw, pgn.BlackWins }
+
+	for _, n := range []int{ 5, 7, 9, 11 } {
+		tour := swiss(n)
+		met := make(map[[2]*tournament.Player]bool)
+		byes := make(map[*tournament.Player]bool)
+
+		for r := 1; r <= n / 2 + 1; r++ {
+			games, err := tour.PairRound()
+
+			if err != nil {
+				t.Fatalf("%d players, round %d: %s", n, r, err)
+			}
+
+			seen := make(map[*tournament.Player]bool)
+
+			for i, g := range games {
+				for _, p := range []*tournament.Player{ g.White, g.Black } {
+					if p != nil && seen[p] {
+						t.Errorf("%d players, round %d: %s paired twice", n, r, p.Name)
+					}
+					seen[p] = true
+				}
+
+				if g.Black == nil {
+					if byes[g.White] {
+						t.Errorf("%d players, round %d: second bye for %s", n, r, g.White.Name)
+					}
+					byes[g.White] = true
+					continue
+				}
+
+				if met[[2]*tournament.Player{ g.White, g.Black }] || met[[2]*tournament.Player{ g.Black, g.White }] {
+					t.Errorf("%d players, round %d: %s and %s meet again", n, r, g.White.Name, g.Black.Name)
+				}
+
+				met[[2]*tournament.Player{ g.White, g.Black }] = true
+				g.Result = results[(r + i) % len(results)]
+			}
+
+			if len(seen) != n + 1 || seen[nil] == false {
+				t.Errorf("%d players, round %d: %d paired and no bye", n, r, len(seen))
+			}
+		}
+	}
+}
+
+// results leaving players who floated in round 3 where they'd float
+// the same way again in round 4, unless the pairing avoids it
+var floatResults = [][]int{
+	{ pgn.Draw, pgn.WhiteWins, pgn.BlackWins },
+	{ pgn.WhiteWins, pgn.WhiteWins, pgn.WhiteWins },
+	{ pgn.WhiteWins, pgn.WhiteWins, pgn.Draw },
+}
+
+// floats of the players paired with a different score, -1 for down
+// and 1 for up
+func floats(scores map[*tournament.Player]float64, games []*tournament.Game) map[*tournament.Player]int {
+	m := make(map[*tournament.Player]int)
+
+	for _, g := range games {
+		if g.Black == nil {
+			continue
+		}
+
+		switch w, b := scores[g.White], scores[g.Black]; {
+			case w > b:
+				m[g.White], m[g.Black] = -1, 1
+				break
+			case w < b:
+				m[g.White], m[g.Black] = 1, -1
+				break
+		}
+	}
+
+	return m
+}
+
+func TestSwissRepeatFloats(t *testing.T) {
+	tour := swiss(7)
+
+	var last map[*tournament.Player]int
+
+	for r := 1; r <= len(floatResults) + 1; r++ {
+		scores := points(tour)
+		games, err := tour.PairRound()
+
+		if err != nil {
+			t.Fatalf("round %d: %s", r, err)
+		}
+
+		if r > len(floatResults) {
+			for p, f := range floats(scores, games) {
+				if last[p] == f {
+					t.Errorf("%s floats %+d in rounds 3 and 4", p.Name, f)
+				}
+			}
+			break
+		}
+
+		last = floats(scores, games)
+
+		// results by board, the bye is last
+		for i, result := range floatResults[r - 1] {
+			games[i].Result = result
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package tournament
+
+import "../pgn"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnfinished = errors.New("the last round isn't finished")
+	ErrFinished = errors.New("every round has been played")
+	ErrNoPairing = errors.New("no pairing is possible")
+)
+
+type System int
+
+const (
+	Swiss System = iota
+	RoundRobin
+)
+
+// A Player of a tournament. Players are numbered by rating when the
+// first round is paired.
+type Player struct {
+	Name string
+	Rating int
+	Number int
+}
+
+// A Game is a pairing of a round and its result, which stays
+// pgn.InProgress until it's played. A game without a black player
+// isn't played: in a Swiss it's a bye, a win for white scoring the
+// tournament's Bye points, and in a round robin it's a rest, which
+// scores nothing. Those points count in the player's score after each
+// round, and so for Progressive, but byes aren't games: Buchholz and
+// Sonneborn-Berger leave them out, as does Standing.Games.
+type Game struct {
+	Round int
+	Board int
+	White, Black *Player
+	Result int
+}
+
+type Tournament struct {
+	Name string
+	System System
+	Rounds int                // for a Swiss, round robins play every round
+	Cycles int                // times every player meets in a round robin
+	Players []*Player
+	Games []*Game             // by round and board
+	Bye float64               // points for a Swiss bye
+	Tiebreaks []Tiebreak      // in order, after points
+}
+
+func New(name string, system System) *Tournament {
+	return &Tournament{
+		Name: name,
+		System: system,
+		Cycles: 1,
+		Bye: 1,
+		Tiebreaks: []Tiebreak{ Buchholz, SonnebornBerger, Progressive },
+	}
+}
+
+// Add enters a player, or returns the one already entered by that
+// name.
+func (t *Tournament) Add(name string, rating int) *Player {
+	if p := t.Player(name); p != nil {
+		return p
+	}
+
+	p := &Player{ Name: name, Rating: rating, Number: len(t.Players) + 1 }
+	t.Players = append(t.Players, p)
+
+	return p
+}
+
+func (t *Tournament) Player(name string) *Player {
+	for _, p := range t.Players {
+		if p.Name == name {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// seed numbers the players by rating, highest first.
+func (t *Tournament) seed() {
+	sort.SliceStable(t.Players, func(i, j int) bool {
+		return t.Players[i].Rating > t.Players[j].Rating
+	})
+
+	for i, p := range t.Players {
+		p.Number = i + 1
+	}
+}
+
+// Played is the number of rounds paired so far.
+func (t *Tournament) Played() int {
+	n := 0
+
+	for _, g := range t.Games {
+		if g.Round > n {
+			n = g.Round
+		}
+	}
+
+	return n
+}
+
+func (t *Tournament) Round(n int) []*Game {
+	games := make([]*Game, 0, len(t.Players) / 2 + 1)
+
+	for _, g := range t.Games {
+		if g.Round == n {
+			games = append(games, g)
+		}
+	}
+
+	return games
+}
+
+// PairRound pairs the next round, once every game of the last one has
+// a result.
+func (t *Tournament) PairRound() ([]*Game, error) {
+	round := t.Played() + 1
+
+	for _, g := range t.Round(round - 1) {
+		if g.Result == pgn.InProgress {
+			return nil, ErrUnfinished
+		}
+	}
+
+	if round == 1 {
+		t.seed()
+	}
+
+	var games []*Game
+	var err error
+
+	switch t.System {
+		case RoundRobin:
+			games, err = t.pairRoundRobin(round)
+			break
+		default:
+			if t.Rounds > 0 && round > t.Rounds {
+				return nil, ErrFinished
+			}
+
+			games, err = t.pairSwiss(round)
+			break
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, g := range games {
+		g.Round, g.Board = round, i + 1
+
+		if g.Black == nil {
+			g.Result = pgn.WhiteWins
+		}
+	}
+
+	t.Games = append(t.Games, games...)
+
+	return games, nil
+}
+
+// SetResult records the result of a game of a round.
+func (t *Tournament) SetResult(round int, white, black string, result int) error {
+	for _, g := range t.Round(round) {
+		if g.White.Name == white && g.Black != nil && g.Black.Name == black {
+			g.Result = result
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no game %s - %s in round %d", white, black, round)
+}
+
+// points each player scored in a game, black's is 0 for a bye. In a
+// round robin everyone rests once a cycle with an odd number of
+// players, which scores nothing.
+func (t *Tournament) points(g *Game) (white, black float64) {
+	switch {
+		case g.Black == nil && t.System == RoundRobin:
+			return 0, 0
+		case g.Black == nil:
+			return t.Bye, 0
+		case g.Result == pgn.WhiteWins:
+			return 1, 0
+		case g.Result == pgn.BlackWins:
+			return 0, 1
+		case g.Result == pgn.Draw:
+			return 0.5, 0.5
+	}
+
+	return 0, 0
+}
+
+// scores of every player after each round
+func (t *Tournament) scores(rounds int) map[*Player][]float64 {
+	scores := make(map[*Player][]float64)
+
+	for _, p := range t.Players {
+		scores[p] = make([]float64, rounds + 1)
+	}
+
+	for r := 1; r <= rounds; r++ {
+		for _, p := range t.Players {
+			scores[p][r] = scores[p][r - 1]
+		}
+
+		for _, g := range t.Round(r) {
+			white, black := t.points(g)
+
+			scores[g.White][r] += white
+
+			if g.Black != nil {
+				scores[g.Black][r] += black
+			}
+		}
+	}
+
+	return scores
+}
+
+// Import adds finished or unfinished games to the tournament, from
+// their White, Black, WhiteElo, BlackElo, Round and Result tags, and
+// enters any new players. Rounds may be numbered like "3" or "3.1".
+// Players are numbered by rating again.
+func (t *Tournament) Import(games []*pgn.PGN) error {
+	for i, game := range games {
+		s := strings.SplitN(game.Tags["Round"], ".", 2)[0]
+		round, err := strconv.Atoi(s)
+
+		if err != nil || round < 1 {
+			return fmt.Errorf("game %d: invalid round %s", i + 1, game.Tags["Round"])
+		}
+
+		white := t.Add(game.Tags["White"], rating(game.Tags["WhiteElo"]))
+		black := t.Add(game.Tags["Black"], rating(game.Tags["BlackElo"]))
+
+		t.Games = append(t.Games, &Game{
+			Round: round,
+			White: white,
+			Black: black,
+			Result: game.Result,
+		})
+	}
+
+	sort.SliceStable(t.Games, func(i, j int) bool {
+		return t.Games[i].Round < t.Games[j].Round
+	})
+
+	for i, g := range t.Games {
+		if g.Board = 1; i > 0 && t.Games[i - 1].Round == g.Round {
+			g.Board = t.Games[i - 1].Board + 1
+		}
+	}
+
+	t.seed()
+
+	return nil
+}
+
+func rating(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,344 @@
+package uci
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrExited = errors.New("engine exited")
+
+// A Client runs an external UCI engine as a subprocess.
+type Client struct {
+	Name string               // from the engine's id name
+	Author string             // from the engine's id author
+	Info func(Info)           // called for each info with a score, if set
+
+	cmd *exec.Cmd
+	in io.WriteCloser
+	lines chan string
+	lock sync.Mutex
+}
+
+// Go is the set of limits sent with a go command. Zero values are
+// not sent.
+type Go struct {
+	Depth int
+	Nodes int
+	MoveTime time.Duration
+	WTime, BTime time.Duration
+	WInc, BInc time.Duration
+	MovesToGo int
+	SearchMoves []string
+	Infinite bool
+}
+
+// Info is the last info reported by the engine for a line.
+type Info struct {
+	Depth int
+	MultiPV int
+	Nodes int
+	Time time.Duration
+	Score int                 // centipawns for the player to move
+	Mate int                  // moves to mate, negative when mated
+	Bound string              // "lowerbound" or "upperbound" if not exact
+	PV []string               // moves in UCI notation
+}
+
+// An Analysis is the result of a go command.
+type Analysis struct {
+	BestMove string
+	Ponder string
+	Lines []Info              // last info of each line, by multipv
+}
+
+// Start runs an engine and waits for it to be ready.
+func Start(path string, args ...string) (*Client, error) {
+	c := &Client{
+		cmd: exec.Command(path, args...),
+		lines: make(chan string, 64),
+	}
+
+	out, err := c.cmd.StdoutPipe()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c.in, err = c.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+
+	if err = c.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// read lines until the engine exits
+	go func() {
+		scanner := bufio.NewScanner(out)
+		scanner.Buffer(make([]byte, 64 * 1024), 1024 * 1024)
+
+		for scanner.Scan() {
+			c.lines <- scanner.Text()
+		}
+
+		close(c.lines)
+	}()
+
+	if err = c.handshake(); err != nil {
+		c.cmd.Process.Kill()
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (c *Client) handshake() error {
+	if err := c.send("uci"); err != nil {
+		return err
+	}
+
+	for {
+		line, ok := <-c.lines
+
+		if ok == false {
+			return ErrExited
+		}
+
+		switch {
+			case strings.HasPrefix(line, "id name "):
+				c.Name = strings.TrimPrefix(line, "id name ")
+				break
+			case strings.HasPrefix(line, "id author "):
+				c.Author = strings.TrimPrefix(line, "id author ")
+				break
+			case line == "uciok":
+				return c.IsReady()
+		}
+	}
+}
+
+func (c *Client) send(format string, args ...interface{}) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, err := fmt.Fprintf(c.in, format + "\n", args...)
+
+	return err
+}
+
+// wait reads lines until one starts with a prefix.
+func (c *Client) wait(prefix string, each func(line string)) (string, error) {
+	for line := range c.lines {
+		if strings.HasPrefix(line, prefix) {
+			return line, nil
+		}
+
+		if each != nil {
+			each(line)
+		}
+	}
+
+	return "", ErrExited
+}
+
+func (c *Client) IsReady() error {
+	if err := c.send("isready"); err != nil {
+		return err
+	}
+
+	_, err := c.wait("readyok", nil)
+
+	return err
+}
+
+func (c *Client) SetOption(name, value string) error {
+	return c.send("setoption name %s value %s", name, value)
+}
+
+func (c *Client) NewGame() error {
+	if err := c.send("ucinewgame"); err != nil {
+		return err
+	}
+
+	return c.IsReady()
+}
+
+// Position sets the position from a FEN (or the start position if
+// empty) and the UCI moves played after it.
+func (c *Client) Position(fen string, moves []string) error {
+	s := "position startpos"
+
+	if fen != "" {
+		s = "position fen " + fen
+	}
+
+	if len(moves) > 0 {
+		s += " moves " + strings.Join(moves, " ")
+	}
+
+	return c.send("%s", s)
+}
+
+// Go searches the current position and waits for the best move.
+func (c *Client) Go(limits Go) (*Analysis, error) {
+	if err := c.send("%s", limits.command()); err != nil {
+		return nil, err
+	}
+
+	analysis := new(Analysis)
+
+	line, err := c.wait("bestmove", func(line string) {
+		if info, ok := ParseInfo(line); ok {
+			analysis.add(info)
+
+			if c.Info != nil {
+				c.Info(info)
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(line)
+
+	if len(fields) > 1 && fields[1] != "(none)" && fields[1] != "0000" {
+		analysis.BestMove = fields[1]
+	}
+
+	if len(fields) > 3 && fields[2] == "ponder" {
+		analysis.Ponder = fields[3]
+	}
+
+	return analysis, nil
+}
+
+// Stop ends a search, Go returns with the best move.
+func (c *Client) Stop() error {
+	return c.send("stop")
+}
+
+// Close asks the engine to quit, and kills it if it doesn't.
+func (c *Client) Close() error {
+	c.send("quit")
+	c.in.Close()
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.cmd.Wait()
+	}()
+
+	select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			c.cmd.Process.Kill()
+			return <-done
+	}
+}
+
+func (limits *Go) command() string {
+	s := "go"
+
+	add := func(name string, n int) {
+		if n > 0 {
+			s += fmt.Sprintf(" %s %d", name, n)
+		}
+	}
+
+	add("depth", limits.Depth)
+	add("nodes", limits.Nodes)
+	add("movetime", int(limits.MoveTime / time.Millisecond))
+	add("wtime", int(limits.WTime / time.Millisecond))
+	add("btime", int(limits.BTime / time.Millisecond))
+	add("winc", int(limits.WInc / time.Millisecond))
+	add("binc", int(limits.BInc / time.Millisecond))
+	add("movestogo", limits.MovesToGo)
+
+	if limits.Infinite {
+		s += " infinite"
+	}
+
+	if len(limits.SearchMoves) > 0 {
+		s += " searchmoves " + strings.Join(limits.SearchMoves, " ")
+	}
+
+	return s
+}
+
+// add keeps the latest info of each line, only when it has a pv
+func (a *Analysis) add(info Info) {
+	if len(info.PV) == 0 {
+		return
+	}
+
+	i := info.MultiPV - 1
+
+	if i < 0 {
+		i = 0
+	}
+
+	for len(a.Lines) <= i {
+		a.Lines = append(a.Lines, Info{})
+	}
+
+	a.Lines[i] = info
+}
+
+// ParseInfo reads an info line with a score.
+func ParseInfo(line string) (Info, bool) {
+	info := Info{ MultiPV: 1 }
+	fields := strings.Fields(line)
+	score := false
+
+	if len(fields) == 0 || fields[0] != "info" {
+		return info, false
+	}
+
+	for i := 1; i < len(fields); i++ {
+		next := func() int {
+			if i + 1 < len(fields) {
+				i++
+				n, _ := strconv.Atoi(fields[i])
+				return n
+			}
+			return 0
+		}
+
+		switch fields[i] {
+			case "depth":   info.Depth = next(); break
+			case "multipv": info.MultiPV = next(); break
+			case "nodes":   info.Nodes = next(); break
+			case "time":    info.Time = time.Duration(next()) * time.Millisecond; break
+			case "score":
+				score = true
+				break
+			case "cp":
+				info.Score = next()
+				break
+			case "mate":
+				info.Mate = next()
+				break
+			case "lowerbound", "upperbound":
+				info.Bound = fields[i]
+				break
+			case "pv":
+				info.PV = fields[i + 1:]
+				i = len(fields)
+				break
+			case "string":
+				return info, false
+		}
+	}
+
+	return info, score
+}
@@ -0,0 +1,470 @@
+package uci
+
+import (
+	"../chess"
+	"../fen"
+	"../search"
+)
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An Engine speaks UCI for the local search, so that it can be used
+// from a chess GUI or match runner.
+type Engine struct {
+	Name string
+	Author string
+
+	game *chess.Game
+	history []uint64          // hashes of the positions before the game's
+	variant chess.Variant
+	searcher *search.Searcher
+	multiPV int
+
+	// writes come from the search goroutine too
+	out io.Writer
+	lock sync.Mutex
+
+	// the running search, closing stop ends it
+	done chan bool
+	stop chan bool
+	infinite bool
+
+	// a ponder search waits for ponderhit, closing hit, to start the
+	// time it would have had
+	pondering bool
+	hit chan bool
+	ponderTime time.Duration
+}
+
+func NewEngine(out io.Writer) *Engine {
+	return &Engine{
+		Name: "gochess",
+		Author: "gochess authors",
+		game: chess.NewGame(),
+		variant: chess.Standard{},
+		searcher: search.New(),
+		multiPV: 1,
+		out: out,
+	}
+}
+
+// Run reads commands until quit or the end of input.
+func (e *Engine) Run(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		if e.Command(scanner.Text()) == false {
+			break
+		}
+	}
+
+	e.wait()
+
+	return scanner.Err()
+}
+
+func (e *Engine) send(format string, args ...interface{}) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	fmt.Fprintf(e.out, format + "\n", args...)
+}
+
+// Command runs a single command and returns false on quit.
+func (e *Engine) Command(line string) bool {
+	fields := strings.Fields(line)
+
+	if len(fields) == 0 {
+		return true
+	}
+
+	switch fields[0] {
+		case "uci":
+			e.send("id name %s", e.Name)
+			e.send("id author %s", e.Author)
+			e.send("option name Hash type spin default %d min 1 max 65536", search.DefaultHashMB)
+			e.send("option name Threads type spin default 1 min 1 max 512")
+			e.send("option name MultiPV type spin default 1 min 1 max 256")
+			e.send("option name Ponder type check default false")
+			e.send("option name UCI_Variant type combo default standard%s", variantOptions())
+			e.send("uciok")
+			break
+		case "isready":
+			e.send("readyok")
+			break
+		case "setoption":
+			e.setOption(fields[1:])
+			break
+		case "ucinewgame":
+			e.wait()
+			e.searcher.Table.Clear()
+			e.game, e.history = chess.NewVariantGame(e.variant), nil
+			break
+		case "position":
+			e.wait()
+			e.position(fields[1:])
+			break
+		case "go":
+			e.wait()
+			e.goSearch(fields[1:])
+			break
+		case "stop":
+			e.halt()
+			break
+		case "ponderhit":
+			e.ponderhit()
+			break
+		case "quit":
+			e.halt()
+			return false
+	}
+
+	return true
+}
+
+func variantOptions() string {
+	var s string
+
+	for _, v := range chess.Variants {
+		s += " var " + strings.ToLower(v.Name())
+	}
+
+	return s
+}
+
+// setoption name <id> [value <x>]
+func (e *Engine) setOption(args []string) {
+	var name, value []string
+	var target *[]string
+
+	for _, arg := range args {
+		switch arg {
+			case "name":  target = &name; break
+			case "value": target = &value; break
+			default:
+				if target != nil {
+					*target = append(*target, arg)
+				}
+				break
+		}
+	}
+
+	n, _ := strconv.Atoi(strings.Join(value, ""))
+
+	// the search reads the options, so wait for it to finish, and keep
+	// spin values within the ranges sent for them
+	switch strings.ToLower(strings.Join(name, " ")) {
+		case "hash":
+			e.wait()
+			e.searcher.Table.Resize(min(max(n, 1), 65536))
+			break
+		case "threads":
+			e.wait()
+			e.searcher.Threads = min(max(n, 1), 512)
+			break
+		case "multipv":
+			e.wait()
+			e.multiPV = min(max(n, 1), 256)
+			break
+		case "uci_variant":
+			if v := chess.VariantByName(strings.Join(value, " ")); v != nil {
+				e.wait()
+				e.variant = v
+				e.game, e.history = chess.NewVariantGame(v), nil
+			}
+			break
+	}
+}
+
+// position [startpos | fen <fen>] [moves <move> ...]
+func (e *Engine) position(args []string) {
+	var g *chess.Game
+
+	i := 0
+
+	for i < len(args) && args[i] != "moves" {
+		i++
+	}
+
+	switch {
+		case len(args) > 0 && args[0] == "startpos":
+			g = chess.NewVariantGame(e.variant)
+			break
+		case len(args) > 1 && args[0] == "fen":
+			g = fen.ParseVariant(strings.Join(args[1:i], " "), e.variant)
+			break
+	}
+
+	if g == nil {
+		e.send("info string invalid position")
+		return
+	}
+
+	var history []uint64
+
+	// play the moves, remembering the positions they leave so that the
+	// search can see repetitions
+	for _, s := range args[min(i + 1, len(args)):] {
+		move := g.ParseUCI(s)
+
+		if move == nil {
+			e.send("info string illegal move %s", s)
+			break
+		}
+
+		history = append(history, g.Hash())
+		g.PerformMove(move)
+	}
+
+	e.game, e.history = g, history
+}
+
+// go [depth <n>] [nodes <n>] [movetime <ms>] [wtime <ms>] [btime <ms>]
+// [winc <ms>] [binc <ms>] [movestogo <n>] [infinite] [ponder]
+// [searchmoves <move> ...]
+func (e *Engine) goSearch(args []string) {
+	limits := search.Limits{
+		MultiPV: e.multiPV,
+		History: e.history,
+	}
+
+	e.infinite, e.pondering, e.hit = false, false, nil
+
+	// only the clock of the player to move matters
+	var clocks, incs [2]time.Duration
+	var timed bool
+
+	ms := func(i int) time.Duration {
+		n, _ := strconv.Atoi(args[i])
+		return time.Duration(n) * time.Millisecond
+	}
+
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+			case "depth":
+				if i++; i < len(args) {
+					limits.Depth, _ = strconv.Atoi(args[i])
+				}
+				break
+			case "nodes":
+				if i++; i < len(args) {
+					limits.Nodes, _ = strconv.Atoi(args[i])
+				}
+				break
+			case "movetime":
+				if i++; i < len(args) {
+					limits.MoveTime = ms(i)
+				}
+				break
+			case "wtime", "btime", "winc", "binc":
+				if i++; i < len(args) {
+					c := chess.White
+
+					if args[i - 1][0] == 'b' {
+						c = chess.Black
+					}
+
+					if strings.HasSuffix(args[i - 1], "time") {
+						clocks[c], timed = ms(i), true
+					} else {
+						incs[c] = ms(i)
+					}
+				}
+				break
+			case "movestogo":
+				if i++; i < len(args) {
+					limits.MovesToGo, _ = strconv.Atoi(args[i])
+				}
+				break
+			case "infinite":
+				e.infinite = true
+				break
+			case "ponder":
+				e.pondering = true
+				break
+			case "searchmoves":
+				for ; i + 1 < len(args); i++ {
+					move := e.game.ParseUCI(args[i + 1])
+
+					if move == nil {
+						break
+					}
+
+					limits.SearchMoves = append(limits.SearchMoves, *move)
+				}
+				break
+		}
+	}
+
+	// an infinite search runs until stopped, whatever the clock
+	if e.infinite {
+		limits.MoveTime = 0
+	} else if timed {
+		limits.Time, limits.Increment = clocks[e.game.Turn], incs[e.game.Turn]
+
+		// a clock run down (or behind) still has to move
+		if limits.Time <= 0 {
+			limits.Time = time.Millisecond
+		}
+	}
+
+	// pondering is on the opponent's time, the clock only starts with
+	// ponderhit
+	if e.pondering {
+		e.hit = make(chan bool)
+		e.ponderTime, _ = limits.TimeLimits()
+		limits.Time, limits.Increment, limits.MoveTime = 0, 0, 0
+	}
+
+	g := *e.game
+	start := time.Now()
+
+	e.done = make(chan bool)
+	e.stop = make(chan bool)
+	limits.Stop = e.stop
+
+	e.searcher.Info = func(result search.Result) {
+		e.info(result, time.Since(start))
+	}
+
+	go func(done, stop, hit chan bool, hold bool) {
+		result := e.searcher.Search(&g, limits)
+
+		// an infinite search only reports its move once stopped, and
+		// pondering once stopped or hit
+		if hold {
+			select {
+				case <-stop: break
+				case <-hit:  break
+			}
+		}
+
+		if len(result.PV) == 0 {
+			e.send("bestmove 0000")
+		} else if len(result.PV) > 1 {
+			e.send("bestmove %s ponder %s", result.Move.UCI(), result.PV[1].UCI())
+		} else {
+			e.send("bestmove %s", result.Move.UCI())
+		}
+
+		close(done)
+	}(e.done, e.stop, e.hit, e.infinite || e.pondering)
+}
+
+// ponderhit: the opponent played the move pondered on, so the search
+// goes on as a normal one, stopping once its time is up.
+func (e *Engine) ponderhit() {
+	if e.done == nil || e.pondering == false {
+		return
+	}
+
+	e.pondering = false
+	close(e.hit)
+
+	// no time limit means searching until stopped, like infinite
+	if e.ponderTime == 0 {
+		e.infinite = true
+		return
+	}
+
+	go func(done, stop chan bool, limit time.Duration) {
+		select {
+			case <-done:
+				break
+			case <-time.After(limit):
+				e.stopSearch(stop)
+				break
+		}
+	}(e.done, e.stop, e.ponderTime)
+}
+
+func (e *Engine) info(result search.Result, elapsed time.Duration) {
+	ms := int(elapsed / time.Millisecond)
+	nps := 0
+
+	if ms > 0 {
+		nps = result.Nodes * 1000 / ms
+	}
+
+	hashfull := e.searcher.Table.Hashfull()
+
+	for i, line := range result.Lines {
+		moves := make([]string, len(line.PV))
+
+		for j := range line.PV {
+			moves[j] = line.PV[j].UCI()
+		}
+
+		e.send("info depth %d multipv %d score %s nodes %d nps %d hashfull %d time %d pv %s",
+			line.Depth,
+			i + 1,
+			Score(line.Score),
+			result.Nodes,
+			nps,
+			hashfull,
+			ms,
+			strings.Join(moves, " "),
+		)
+	}
+}
+
+// Score formats a search score as UCI: centipawns or moves to mate.
+func Score(score int) string {
+	if search.IsMate(score) {
+		return fmt.Sprintf("mate %d", search.MateMoves(score))
+	}
+
+	return fmt.Sprintf("cp %d", score)
+}
+
+// halt stops the running search and waits for its best move.
+func (e *Engine) halt() {
+	if e.done == nil {
+		return
+	}
+
+	e.stopSearch(e.stop)
+	<-e.done
+
+	e.done = nil
+	e.infinite, e.pondering = false, false
+}
+
+// stopSearch closes the stop channel of a search, which both stop and
+// the time running out after ponderhit can do.
+func (e *Engine) stopSearch(stop chan bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	select {
+		case <-stop:
+			break
+		default:
+			close(stop)
+			break
+	}
+}
+
+// wait lets a running search finish, except infinite and ponder
+// searches, which are stopped.
+func (e *Engine) wait() {
+	if e.done == nil {
+		return
+	}
+
+	if e.infinite || e.pondering {
+		e.halt()
+		return
+	}
+
+	<-e.done
+	e.done = nil
+}